@@ -0,0 +1,68 @@
+// Copyright 2019 HAProxy Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package v3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:path=staticresponses,singular=staticresponse,scope=Namespaced
+
+// StaticResponse is a specification for a StaticResponse resource
+type StaticResponse struct {
+	Spec              StaticResponseSpec `json:"spec"`
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+}
+
+// StaticResponseSpec defines the response HAProxy returns, without any Service,
+// to the requests of the ingress paths having this resource as backend.
+type StaticResponseSpec struct {
+	// StatusCode of the response, 200 by default or 302 for a redirect
+	StatusCode int64 `json:"status_code,omitempty"`
+	// ContentType of the body
+	ContentType string `json:"content_type,omitempty"`
+	// Body of the response
+	Body string `json:"body,omitempty"`
+	// Headers added to the response
+	Headers []StaticResponseHeader `json:"headers,omitempty"`
+	// Redirect requests instead of returning a body
+	Redirect *StaticResponseRedirect `json:"redirect,omitempty"`
+}
+
+// StaticResponseHeader is a header added to a static response
+type StaticResponseHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// StaticResponseRedirect defines the location requests are redirected to
+type StaticResponseRedirect struct {
+	// Location is the absolute URL of the redirection
+	Location string `json:"location"`
+	// KeepPath appends the path and query string of the request to the location
+	KeepPath bool `json:"keep_path,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// StaticResponseList is a list of StaticResponse resources
+type StaticResponseList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []StaticResponse `json:"items"`
+}
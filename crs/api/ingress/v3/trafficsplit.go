@@ -0,0 +1,65 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:path=trafficsplits,singular=trafficsplit,scope=Namespaced
+
+// TrafficSplit is a specification for a TrafficSplit resource
+type TrafficSplit struct {
+	Spec              TrafficSplitSpec `json:"spec"`
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+}
+
+// TrafficSplitSpec defines how the requests of the ingress paths having this resource
+// as backend are shared between Services of the namespace.
+type TrafficSplitSpec struct {
+	// Services sharing the requests according to their weight
+	Services []TrafficSplitService `json:"services"`
+	// Cookie keeps a client on the Service first selected for it, no stickiness when empty
+	Cookie string `json:"cookie,omitempty"`
+}
+
+// TrafficSplitService is a Service receiving a share of the requests
+type TrafficSplitService struct {
+	// Name of the Service
+	Name string `json:"name"`
+	// Port of the Service
+	Port TrafficSplitServicePort `json:"port"`
+	// Weight of the Service relative to the weights of the other Services
+	Weight int64 `json:"weight"`
+}
+
+// TrafficSplitServicePort is the port of a Service, by name or by number
+type TrafficSplitServicePort struct {
+	Name   string `json:"name,omitempty"`
+	Number int64  `json:"number,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TrafficSplitList is a list of TrafficSplit resources
+type TrafficSplitList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []TrafficSplit `json:"items"`
+}
@@ -65,6 +65,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&FrontendList{},
 		&Global{},
 		&GlobalList{},
+		&StaticResponse{},
+		&StaticResponseList{},
+		&TrafficSplit{},
+		&TrafficSplitList{},
 		&TCP{},
 		&TCPList{},
 		&ValidationRules{},
@@ -20,6 +20,12 @@ var ValidationRules []byte
 //go:embed ingress.v3.haproxy.org_frontends.yaml
 var Frontends []byte
 
+//go:embed ingress.v3.haproxy.org_staticresponses.yaml
+var StaticResponses []byte
+
+//go:embed ingress.v3.haproxy.org_trafficsplits.yaml
+var TrafficSplits []byte
+
 func GetCRDs() map[string][]byte {
 	return map[string][]byte{
 		"defaults.ingress.v3.haproxy.org":        Defaults,
@@ -28,5 +34,7 @@ func GetCRDs() map[string][]byte {
 		"tcps.ingress.v3.haproxy.org":            TCPs,
 		"validationrules.ingress.v3.haproxy.org": ValidationRules,
 		"frontends.ingress.v3.haproxy.org":       Frontends,
+		"staticresponses.ingress.v3.haproxy.org": StaticResponses,
+		"trafficsplits.ingress.v3.haproxy.org":   TrafficSplits,
 	}
 }
@@ -43,10 +43,18 @@ func (c *FakeIngressV3) Globals(namespace string) v3.GlobalInterface {
 	return &FakeGlobals{c, namespace}
 }
 
+func (c *FakeIngressV3) StaticResponses(namespace string) v3.StaticResponseInterface {
+	return &FakeStaticResponses{c, namespace}
+}
+
 func (c *FakeIngressV3) TCPs(namespace string) v3.TCPInterface {
 	return &FakeTCPs{c, namespace}
 }
 
+func (c *FakeIngressV3) TrafficSplits(namespace string) v3.TrafficSplitInterface {
+	return &FakeTrafficSplits{c, namespace}
+}
+
 func (c *FakeIngressV3) ValidationRules(namespace string) v3.ValidationRulesInterface {
 	return &FakeValidationRules{c, namespace}
 }
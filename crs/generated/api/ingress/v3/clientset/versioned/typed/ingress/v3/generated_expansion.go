@@ -25,6 +25,10 @@ type FrontendExpansion interface{}
 
 type GlobalExpansion interface{}
 
+type StaticResponseExpansion interface{}
+
 type TCPExpansion interface{}
 
+type TrafficSplitExpansion interface{}
+
 type ValidationRulesExpansion interface{}
@@ -31,7 +31,9 @@ type IngressV3Interface interface {
 	DefaultsGetter
 	FrontendsGetter
 	GlobalsGetter
+	StaticResponsesGetter
 	TCPsGetter
+	TrafficSplitsGetter
 	ValidationRulesGetter
 }
 
@@ -56,10 +58,18 @@ func (c *IngressV3Client) Globals(namespace string) GlobalInterface {
 	return newGlobals(c, namespace)
 }
 
+func (c *IngressV3Client) StaticResponses(namespace string) StaticResponseInterface {
+	return newStaticResponses(c, namespace)
+}
+
 func (c *IngressV3Client) TCPs(namespace string) TCPInterface {
 	return newTCPs(c, namespace)
 }
 
+func (c *IngressV3Client) TrafficSplits(namespace string) TrafficSplitInterface {
+	return newTrafficSplits(c, namespace)
+}
+
 func (c *IngressV3Client) ValidationRules(namespace string) ValidationRulesInterface {
 	return newValidationRules(c, namespace)
 }
@@ -60,8 +60,12 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Ingress().V3().Frontends().Informer()}, nil
 	case v3.SchemeGroupVersion.WithResource("globals"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Ingress().V3().Globals().Informer()}, nil
+	case v3.SchemeGroupVersion.WithResource("staticresponses"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Ingress().V3().StaticResponses().Informer()}, nil
 	case v3.SchemeGroupVersion.WithResource("tcps"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Ingress().V3().TCPs().Informer()}, nil
+	case v3.SchemeGroupVersion.WithResource("trafficsplits"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Ingress().V3().TrafficSplits().Informer()}, nil
 	case v3.SchemeGroupVersion.WithResource("validationrules"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Ingress().V3().ValidationRules().Informer()}, nil
 
@@ -31,8 +31,12 @@ type Interface interface {
 	Frontends() FrontendInformer
 	// Globals returns a GlobalInformer.
 	Globals() GlobalInformer
+	// StaticResponses returns a StaticResponseInformer.
+	StaticResponses() StaticResponseInformer
 	// TCPs returns a TCPInformer.
 	TCPs() TCPInformer
+	// TrafficSplits returns a TrafficSplitInformer.
+	TrafficSplits() TrafficSplitInformer
 	// ValidationRules returns a ValidationRulesInformer.
 	ValidationRules() ValidationRulesInformer
 }
@@ -68,11 +72,21 @@ func (v *version) Globals() GlobalInformer {
 	return &globalInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// StaticResponses returns a StaticResponseInformer.
+func (v *version) StaticResponses() StaticResponseInformer {
+	return &staticResponseInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // TCPs returns a TCPInformer.
 func (v *version) TCPs() TCPInformer {
 	return &tCPInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// TrafficSplits returns a TrafficSplitInformer.
+func (v *version) TrafficSplits() TrafficSplitInformer {
+	return &trafficSplitInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // ValidationRules returns a ValidationRulesInformer.
 func (v *version) ValidationRules() ValidationRulesInformer {
 	return &validationRulesInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
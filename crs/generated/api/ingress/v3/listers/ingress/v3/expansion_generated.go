@@ -49,6 +49,14 @@ type GlobalListerExpansion interface{}
 // GlobalNamespaceLister.
 type GlobalNamespaceListerExpansion interface{}
 
+// StaticResponseListerExpansion allows custom methods to be added to
+// StaticResponseLister.
+type StaticResponseListerExpansion interface{}
+
+// StaticResponseNamespaceListerExpansion allows custom methods to be added to
+// StaticResponseNamespaceLister.
+type StaticResponseNamespaceListerExpansion interface{}
+
 // TCPListerExpansion allows custom methods to be added to
 // TCPLister.
 type TCPListerExpansion interface{}
@@ -57,6 +65,14 @@ type TCPListerExpansion interface{}
 // TCPNamespaceLister.
 type TCPNamespaceListerExpansion interface{}
 
+// TrafficSplitListerExpansion allows custom methods to be added to
+// TrafficSplitLister.
+type TrafficSplitListerExpansion interface{}
+
+// TrafficSplitNamespaceListerExpansion allows custom methods to be added to
+// TrafficSplitNamespaceLister.
+type TrafficSplitNamespaceListerExpansion interface{}
+
 // ValidationRulesListerExpansion allows custom methods to be added to
 // ValidationRulesLister.
 type ValidationRulesListerExpansion interface{}
@@ -0,0 +1,64 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build e2e_parallel
+
+package canarydeployment
+
+import (
+	"io"
+	"strings"
+
+	"github.com/haproxytech/kubernetes-ingress/deploy/tests/e2e"
+)
+
+func (suite *CanaryDeploymentSuite) Test_Response_With_Canary_Annotations() {
+	suite.tmplData.StagingRouteACL = "rand(100) lt 0"
+	suite.Require().NoError(suite.test.Apply("config/deploy.yaml.tmpl", suite.test.GetNS(), suite.tmplData))
+	tests := []struct {
+		name    string
+		weight  int
+		header  map[string][]string
+		staging bool
+	}{
+		{"no-criteria", 0, map[string][]string{}, false},
+		{"header-always", 0, map[string][]string{"X-Canary": {"always"}}, true},
+		{"header-other", 0, map[string][]string{"X-Canary": {"other"}}, false},
+		{"cookie-always", 0, map[string][]string{"Cookie": {"canary=always"}}, true},
+		{"weight", 100, map[string][]string{}, true},
+		{"weight-header-never", 100, map[string][]string{"X-Canary": {"never"}}, false},
+		{"weight-cookie-never", 100, map[string][]string{"Cookie": {"canary=never"}}, false},
+	}
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.tmplData.CanaryWeight = test.weight
+			suite.Require().NoError(suite.test.Apply("config/canary-ingress.yaml.tmpl", suite.test.GetNS(), suite.tmplData))
+			suite.Eventually(func() bool {
+				suite.client.Req.Header = test.header
+				res, cls, err := suite.client.Do()
+				if res == nil {
+					suite.T().Log(err)
+					return false
+				}
+				defer cls()
+				if res.StatusCode != 200 {
+					return false
+				}
+				body, _ := io.ReadAll(res.Body)
+				return strings.HasPrefix(string(body), "http-echo-staging") == test.staging
+			}, e2e.WaitDuration, e2e.TickDuration)
+		})
+	}
+	suite.client.Req.Header = map[string][]string{}
+}
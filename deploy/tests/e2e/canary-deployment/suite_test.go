@@ -36,6 +36,7 @@ type CanaryDeploymentSuite struct {
 type tmplData struct {
 	Host            string
 	StagingRouteACL string
+	CanaryWeight    int
 }
 
 func (suite *CanaryDeploymentSuite) SetupSuite() {
@@ -0,0 +1,72 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build e2e_parallel
+
+package ingressmatch
+
+import (
+	"io"
+	"strings"
+
+	"github.com/haproxytech/kubernetes-ingress/deploy/tests/e2e"
+)
+
+var regexIngressRules = []IngressRule{
+	{Service: "http-echo-1", Host: "regex.haproxy", Path: "/", PathType: "Prefix"},
+	{Service: "http-echo-2", Host: "regex.haproxy", Path: "/api/v[0-9]+/users", PathType: "ImplementationSpecific"},
+	{Service: "http-echo-3", Host: "regex.haproxy", Path: `/static/.*\.css$`, PathType: "ImplementationSpecific"},
+}
+
+var regexTests = []test{
+	{regexIngressRules[0].Service, "regex.haproxy", []string{"/", "/api/vx/users", "/API/v1/users", "/static/a.css.map"}},
+	{regexIngressRules[1].Service, "regex.haproxy", []string{"/api/v1/users", "/api/v12/users/42"}},
+	{regexIngressRules[2].Service, "regex.haproxy", []string{"/static/a.css", "/static/a/b.css"}},
+}
+
+func (suite *IngressMatchSuite) Test_Http_MatchPathRegex() {
+	suite.tmplData.Apps = make([]int, len(regexIngressRules))
+	for i := 0; i < len(regexIngressRules); i++ {
+		suite.tmplData.Apps[i] = i + 1
+	}
+	suite.tmplData.Rules = regexIngressRules
+	suite.Require().NoError(suite.test.Apply("config/deploy.yaml.tmpl", suite.test.GetNS(), suite.tmplData))
+	suite.Require().NoError(suite.test.Apply("config/ingress-regex.yaml.tmpl", suite.test.GetNS(), suite.tmplData))
+
+	for _, test := range regexTests {
+		for _, path := range test.paths {
+			suite.Run("test="+test.host+path, func() {
+				suite.Eventually(func() bool {
+					suite.client.Host = test.host
+					suite.client.Path = path
+					res, cls, err := suite.client.Do()
+					if res == nil {
+						suite.T().Log(err)
+						return false
+					}
+					defer cls()
+					body, err := io.ReadAll(res.Body)
+					if err != nil {
+						return false
+					}
+					pass := strings.HasPrefix(string(body), test.target)
+					if !pass {
+						suite.T().Logf("Expected %s in response but got %s", test.target, string(body))
+					}
+					return pass
+				}, e2e.WaitDuration, e2e.TickDuration)
+			})
+		}
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tnr
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	c "github.com/haproxytech/kubernetes-ingress/pkg/controller"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/env"
+	"github.com/haproxytech/kubernetes-ingress/pkg/ingress"
+	k8ssync "github.com/haproxytech/kubernetes-ingress/pkg/k8s/sync"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+	"github.com/jessevdk/go-flags"
+	"github.com/stretchr/testify/suite"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// Namespace is the namespace of the resources sent to the controller.
+const Namespace = "ns"
+
+var haproxyConfig = `global
+daemon
+master-worker
+pidfile /var/run/haproxy.pid
+stats socket /var/run/haproxy-runtime-api.sock level admin expose-fd listeners
+default-path config
+
+peers localinstance
+ peer local 127.0.0.1:10000
+
+frontend https
+mode http
+http-request set-var(txn.base) base
+use_backend %[var(txn.path_match),field(1,.)]
+
+frontend http
+mode http
+http-request set-var(txn.base) base
+use_backend %[var(txn.path_match),field(1,.)]
+
+frontend healthz
+mode http
+monitor-uri /healthz
+option dontlog-normal
+
+frontend stats
+  mode http
+  stats enable
+  stats uri /
+  stats refresh 10s
+  http-request set-var(txn.base) base
+  http-request use-service prometheus-exporter if { path /metrics }
+ `
+
+type updateStatusManager struct{}
+
+func (m *updateStatusManager) AddIngress(ingress *ingress.Ingress) {}
+func (m *updateStatusManager) Update(k store.K8s, h haproxy.HAProxy, a annotations.Annotations) (err error) {
+	return err
+}
+
+// BaseSuite runs each test against its own controller, fed with store resources through its event channel.
+type BaseSuite struct {
+	suite.Suite
+	TempDir   string
+	EventChan chan k8ssync.SyncDataEvent
+}
+
+// BeforeTest creates a tempDir for haproxy config + maps + ....
+func (suite *BaseSuite) BeforeTest(suiteName, testName string) {
+	tempDir, err := os.MkdirTemp("", "tnr-"+testName+"-*")
+	if err != nil {
+		suite.T().Fatalf("Suite '%s': Test '%s' : error : %s", suiteName, testName, err)
+	}
+	suite.TempDir = tempDir
+	suite.T().Logf("temporary configuration dir %s", suite.TempDir)
+}
+
+// StartController starts a controller writing its configuration in the tempDir of the test,
+// with the additional start parameters, then sends it the namespace and the ingress class of the test resources.
+func (suite *BaseSuite) StartController(args ...string) {
+	var osArgs utils.OSArgs
+	os.Args = append([]string{os.Args[0], "-e", "-t", "--config-dir=" + suite.TempDir}, args...)
+	parser := flags.NewParser(&osArgs, flags.IgnoreUnknown)
+	_, errParsing := parser.Parse() //nolint:ifshort
+	if errParsing != nil {
+		suite.T().Fatal(errParsing)
+	}
+
+	s := store.NewK8sStore(osArgs)
+
+	haproxyEnv := env.Env{
+		CfgDir: suite.TempDir,
+		Proxies: env.Proxies{
+			FrontHTTP:  "http",
+			FrontHTTPS: "https",
+			FrontSSL:   "ssl",
+			BackSSL:    "ssl-backend",
+		},
+	}
+
+	suite.EventChan = make(chan k8ssync.SyncDataEvent, watch.DefaultChanSize*6)
+	controller := c.NewBuilder().
+		WithHaproxyCfgFile([]byte(haproxyConfig)).
+		WithEventChan(suite.EventChan).
+		WithStore(s).
+		WithHaproxyEnv(haproxyEnv).
+		WithUpdateStatusManager(&updateStatusManager{}).
+		WithArgs(osArgs).Build()
+
+	go controller.Start()
+
+	suite.Send(
+		&store.Namespace{Name: Namespace, Status: store.ADDED},
+		&store.IngressClass{
+			Name:       "haproxy",
+			Controller: "haproxy.org/ingress-controller",
+			Status:     store.ADDED,
+		},
+	)
+}
+
+// Send sends the events of the store resources to the controller.
+func (suite *BaseSuite) Send(resources ...any) {
+	for _, resource := range resources {
+		var event k8ssync.SyncDataEvent
+		switch r := resource.(type) {
+		case *store.Namespace:
+			event = k8ssync.SyncDataEvent{SyncType: k8ssync.NAMESPACE, Namespace: r.Name, Data: r}
+		case *store.IngressClass:
+			event = k8ssync.SyncDataEvent{SyncType: k8ssync.INGRESS_CLASS, Data: r}
+		case *store.Endpoints:
+			event = k8ssync.SyncDataEvent{SyncType: k8ssync.ENDPOINTS, Namespace: r.Namespace, Data: r}
+		case *store.Service:
+			event = k8ssync.SyncDataEvent{SyncType: k8ssync.SERVICE, Namespace: r.Namespace, Data: r}
+		case *store.Secret:
+			event = k8ssync.SyncDataEvent{SyncType: k8ssync.SECRET, Namespace: r.Namespace, Data: r}
+		case *store.ConfigMap:
+			event = k8ssync.SyncDataEvent{SyncType: k8ssync.CONFIGMAP, Namespace: r.Namespace, Data: r}
+		case *store.Ingress:
+			event = k8ssync.SyncDataEvent{SyncType: k8ssync.INGRESS, Namespace: r.Namespace, Data: r}
+		default:
+			suite.T().Fatalf("unexpected resource %T", resource)
+		}
+		suite.EventChan <- event
+	}
+}
+
+// Sync sends the events of the store resources to the controller and waits until it has processed them.
+func (suite *BaseSuite) Sync(resources ...any) {
+	suite.Send(resources...)
+	controllerHasWorked := make(chan struct{})
+	suite.EventChan <- k8ssync.SyncDataEvent{SyncType: k8ssync.COMMAND, EventProcessed: controllerHasWorked}
+	<-controllerHasWorked
+}
+
+// HaproxyConfig returns the content of the haproxy.cfg written by the controller.
+func (suite *BaseSuite) HaproxyConfig() string {
+	contents, err := os.ReadFile(filepath.Join(suite.TempDir, "haproxy.cfg"))
+	if err != nil {
+		suite.T().Error(err.Error())
+	}
+	return string(contents)
+}
+
+// NewService returns a service with a single TCP port, to be sent with its endpoints.
+func NewService(name, portName string, port int64, annotations map[string]string) *store.Service {
+	return &store.Service{
+		Name:        name,
+		Namespace:   Namespace,
+		Annotations: annotations,
+		Ports: []store.ServicePort{
+			{
+				Name:     portName,
+				Protocol: "TCP",
+				Port:     port,
+				Status:   store.ADDED,
+			},
+		},
+		Status: store.ADDED,
+	}
+}
+
+// NewEndpoints returns the endpoints of the service with a single address.
+func NewEndpoints(service *store.Service, address string) *store.Endpoints {
+	ports := map[string]*store.PortEndpoints{}
+	for _, port := range service.Ports {
+		ports[port.Name] = &store.PortEndpoints{
+			Port:      port.Port,
+			Addresses: map[string]struct{}{address: {}},
+		}
+	}
+	return &store.Endpoints{
+		SliceName: service.Name,
+		Service:   service.Name,
+		Namespace: service.Namespace,
+		Ports:     ports,
+		Status:    store.ADDED,
+	}
+}
+
+// NewIngress returns an ingress routing the prefix path of the host to the first port of the service.
+func NewIngress(name, host, path string, service *store.Service, annotations map[string]string) *store.Ingress {
+	prefixPathType := networkingv1.PathTypePrefix
+	return &store.Ingress{
+		IngressCore: store.IngressCore{
+			APIVersion:  store.NETWORKINGV1,
+			Name:        name,
+			Namespace:   Namespace,
+			Class:       "haproxy",
+			Annotations: annotations,
+			Rules: map[string]*store.IngressRule{
+				host: {
+					Host: host,
+					Paths: map[string]*store.IngressPath{
+						string(prefixPathType) + "-" + path: {
+							Path:          path,
+							PathTypeMatch: string(prefixPathType),
+							SvcNamespace:  service.Namespace,
+							SvcPortString: service.Ports[0].Name,
+							SvcName:       service.Name,
+						},
+					},
+				},
+			},
+		},
+		Status: store.ADDED,
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package canary
+
+import (
+	"regexp"
+	"strings"
+)
+
+func (suite *CanarySuite) TestCanaryRules() {
+	suite.CanaryFixture()
+	suite.Run("Rules of a canary ingress should apply to the requests of its route", func() {
+		contents := suite.HaproxyConfig()
+		setHeader := regexp.MustCompile(`http-request set-header X-Canary "true" if { var\(txn.path_match\) -m dom (\w+) }`).FindAllStringSubmatch(contents, -1)
+		suite.Require().Len(setHeader, 2, "set-header rule should be in both http and https frontends")
+		condition := "{ var(txn.host) -m str shop.example.local } { path -m beg / } { var(txn.canary_rand) -m int lt 20 }"
+		suite.Contains(contents, "http-request set-var(txn.path_match) str(ns_svc_shop-canary_https."+setHeader[0][1]+") if "+condition+" !{ var(txn.custom_route) -m found }")
+		suite.Contains(contents, "use_backend ns_svc_shop-canary_https if "+condition)
+	})
+	suite.Run("Canary weight should be drawn once per request", func() {
+		contents := suite.HaproxyConfig()
+		suite.Equal(2, strings.Count(contents, "http-request set-var(txn.canary_rand) rand(100)\n"))
+		suite.NotContains(contents, "{ rand(100)")
+	})
+}
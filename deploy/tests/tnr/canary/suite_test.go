@@ -0,0 +1,51 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package canary
+
+import (
+	"testing"
+	"time"
+
+	"github.com/haproxytech/kubernetes-ingress/deploy/tests/tnr"
+	"github.com/stretchr/testify/suite"
+)
+
+type CanarySuite struct {
+	tnr.BaseSuite
+}
+
+func TestCanary(t *testing.T) {
+	suite.Run(t, new(CanarySuite))
+}
+
+// CanaryFixture serves a host with a production ingress and a canary ingress setting a request header.
+func (suite *CanarySuite) CanaryFixture() {
+	suite.StartController()
+	service := tnr.NewService("shop-service", "https", 8443, nil)
+	canaryService := tnr.NewService("shop-canary", "https", 8443, nil)
+	production := tnr.NewIngress("shop-ingress", "shop.example.local", "/", service, nil)
+	production.CreationTime = time.Now().Add(-time.Hour)
+	canary := tnr.NewIngress("shop-canary-ingress", "shop.example.local", "/", canaryService, map[string]string{
+		"canary":             "true",
+		"canary-weight":      "20",
+		"request-set-header": "X-Canary true",
+	})
+	canary.CreationTime = time.Now()
+	suite.Sync(
+		tnr.NewEndpoints(service, "10.244.0.12"), service,
+		tnr.NewEndpoints(canaryService, "10.244.0.13"), canaryService,
+		production, canary,
+	)
+}
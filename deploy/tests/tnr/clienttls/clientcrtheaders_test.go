@@ -0,0 +1,61 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clienttls
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+)
+
+func (suite *ClientTLSSuite) TestClientCrtHeaders() {
+	crt, key := suite.certificate("app.example.local")
+	caCrt, _ := suite.certificate("Partners CA")
+	suite.ClientTLSFixture(map[string]string{
+		"client-ca":          "partners-ca",
+		"client-crt-headers": "verify, cn X-Client-Name, cert",
+	},
+		&store.Secret{Name: "app-tls", Data: map[string][]byte{"tls.crt": crt, "tls.key": key}},
+		&store.Secret{Name: "partners-ca", Data: map[string][]byte{"tls.crt": caCrt}},
+	)
+	suite.Run("Client certificate fields should be forwarded in headers replacing the headers of the client", func() {
+		contents, err := os.ReadFile(filepath.Join(suite.TempDir, "haproxy.cfg"))
+		if err != nil {
+			suite.T().Error(err.Error())
+		}
+		cfg := string(contents)
+		rules := []string{
+			// Client certificate verification precedes the headers
+			"http-request deny deny_status 421 if { var(txn.path_match) -m dom ",
+			"http-request del-header X-SSL-Client-Verify if { var(txn.path_match) -m dom ",
+			"http-request del-header X-Client-Name if { var(txn.path_match) -m dom ",
+			"http-request del-header X-SSL-Client-Cert if { var(txn.path_match) -m dom ",
+			"http-request set-header X-SSL-Client-Verify NONE if { var(txn.path_match) -m dom ",
+			"http-request set-header X-SSL-Client-Verify SUCCESS if { var(txn.path_match) -m dom ",
+			"http-request set-header X-SSL-Client-Verify FAILED:%[ssl_c_verify] if { var(txn.path_match) -m dom ",
+			"http-request set-header X-Client-Name %[ssl_c_s_dn(cn)] if { var(txn.path_match) -m dom ",
+			"http-request set-header X-SSL-Client-Cert %[ssl_c_der,base64] if { var(txn.path_match) -m dom ",
+		}
+		previous := -1
+		for _, rule := range rules {
+			suite.Exactly(2, strings.Count(cfg, rule), "expected '%s' in http and https frontends", rule)
+			index := strings.Index(cfg, rule)
+			suite.Greater(index, previous, "'%s' should follow the previous rules", rule)
+			previous = index
+		}
+	})
+}
@@ -0,0 +1,104 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clienttls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+)
+
+func (suite *ClientTLSSuite) certificate(commonName string) (crt, key []byte) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{commonName},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(privateKey)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func (suite *ClientTLSSuite) TestClientTLS() {
+	crt, key := suite.certificate("app.example.local")
+	caCrt, _ := suite.certificate("Partners CA")
+	suite.ClientTLSFixture(map[string]string{
+		"client-ca":  "partners-ca",
+		"client-crl": "partners-ca",
+	},
+		&store.Secret{Name: "app-tls", Data: map[string][]byte{"tls.crt": crt, "tls.key": key}},
+		&store.Secret{Name: "partners-ca", Data: map[string][]byte{"tls.crt": caCrt, "ca.crl": []byte("crl")}},
+	)
+	suite.Run("Certificates of the ingress should be served from the crt-list with client certificate verification", func() {
+		contents, err := os.ReadFile(filepath.Join(suite.TempDir, "haproxy.cfg"))
+		if err != nil {
+			suite.T().Error(err.Error())
+		}
+		cfg := string(contents)
+		certsDir := filepath.Join(suite.TempDir, "certs")
+		crtList := filepath.Join(certsDir, "frontend.crt-list")
+		suite.Contains(cfg, "crt-list "+crtList)
+
+		_, err = os.Stat(filepath.Join(certsDir, "frontend", "ns_app-tls.pem"))
+		suite.True(os.IsNotExist(err), "certificate should not be served from the frontend certificates directory")
+
+		contents, err = os.ReadFile(crtList)
+		if err != nil {
+			suite.T().Fatal(err.Error())
+		}
+		suite.Equal(filepath.Join(certsDir, "crt-list", "ns_app-tls.pem")+
+			" [ca-file "+filepath.Join(certsDir, "ca", "ns_partners-ca.pem")+
+			" verify required crl-file "+filepath.Join(certsDir, "crl", "ns_partners-ca.pem")+
+			"] app.example.local\n", string(contents))
+
+		rules := []string{
+			"http-request deny deny_status 421 if { var(txn.path_match) -m dom ",
+			// Plain HTTP requests are let through to the ssl-redirect rule
+			"{ ssl_fc } !{ ssl_fc_sni -m str -i app.example.local }",
+			"http-request deny deny_status 403 if { var(txn.path_match) -m dom ",
+			"{ ssl_fc } !{ ssl_c_used }",
+		}
+		previous := -1
+		for _, rule := range rules {
+			suite.Exactly(2, strings.Count(cfg, rule), "expected '%s' in http and https frontends", rule)
+			index := strings.Index(cfg, rule)
+			suite.Greater(index, previous, "'%s' should follow the previous rules", rule)
+			previous = index
+		}
+		suite.Greater(strings.Index(cfg, "http-request redirect location https://"), previous, "ssl-redirect should follow the client certificate verification")
+	})
+}
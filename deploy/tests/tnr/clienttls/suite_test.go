@@ -0,0 +1,50 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clienttls
+
+import (
+	"testing"
+
+	"github.com/haproxytech/kubernetes-ingress/deploy/tests/tnr"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/stretchr/testify/suite"
+)
+
+type ClientTLSSuite struct {
+	tnr.BaseSuite
+}
+
+func TestClientTLS(t *testing.T) {
+	suite.Run(t, new(ClientTLSSuite))
+}
+
+func (suite *ClientTLSSuite) ClientTLSFixture(ingressAnnotations map[string]string, secrets ...*store.Secret) {
+	suite.StartController()
+	service := tnr.NewService("app-service", "https", 8443, nil)
+	suite.Send(tnr.NewEndpoints(service, "10.244.0.12"), service)
+	for _, secret := range secrets {
+		secret.Namespace = tnr.Namespace
+		secret.Status = store.ADDED
+		suite.Send(secret)
+	}
+	ingress := tnr.NewIngress("app-ingress", "app.example.local", "/", service, ingressAnnotations)
+	ingress.TLS = map[string]*store.IngressTLS{
+		"app.example.local": {
+			Host:       "app.example.local",
+			SecretName: "app-tls",
+		},
+	}
+	suite.Sync(ingress)
+}
@@ -0,0 +1,78 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errorpages
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func (suite *ErrorPagesSuite) TestErrorPages() {
+	suite.ErrorPagesFixture(map[string]string{"error-pages": "ns/branded-errors"})
+	suite.Run("Error pages of the ConfigMap should be written and used by http-error rules of the backend", func() {
+		contents, err := os.ReadFile(filepath.Join(suite.TempDir, "haproxy.cfg"))
+		if err != nil {
+			suite.T().Error(err.Error())
+		}
+		for _, code := range []string{"404", "503"} {
+			page := filepath.Join(suite.TempDir, "errorpages", "ns_branded-errors", code)
+			rule := "http-error status " + code + " content-type text/html file " + page
+			suite.True(strings.Contains(string(contents), rule), "expected '%s' in haproxy.cfg", rule)
+			_, err = os.Stat(page)
+			suite.NoError(err, "error page of status %s should be written", code)
+		}
+	})
+}
+
+func (suite *ErrorPagesSuite) TestErrorPagesNamespace() {
+	suite.ErrorPagesFixture(map[string]string{"error-pages": "branded-errors"})
+	suite.Run("ConfigMap should default to the namespace of the service", func() {
+		contents, err := os.ReadFile(filepath.Join(suite.TempDir, "haproxy.cfg"))
+		if err != nil {
+			suite.T().Error(err.Error())
+		}
+		page := filepath.Join(suite.TempDir, "errorpages", "ns_branded-errors", "503")
+		rule := "http-error status 503 content-type text/html file " + page
+		suite.True(strings.Contains(string(contents), rule), "expected '%s' in haproxy.cfg", rule)
+	})
+}
+
+func (suite *ErrorPagesSuite) TestErrorPagesOtherNamespace() {
+	suite.ErrorPagesFixture(map[string]string{"error-pages": "other/branded-errors"})
+	suite.Run("ConfigMap of another namespace should be ignored in service annotations", func() {
+		contents, err := os.ReadFile(filepath.Join(suite.TempDir, "haproxy.cfg"))
+		if err != nil {
+			suite.T().Error(err.Error())
+		}
+		suite.False(strings.Contains(string(contents), "http-error status"), "unexpected http-error rule in haproxy.cfg")
+	})
+}
+
+func (suite *ErrorPagesSuite) TestErrorPagesRedirect() {
+	suite.ErrorPagesFixture(map[string]string{
+		"error-pages":          "ns/branded-errors",
+		"error-pages-redirect": "503 https://status.example.local/",
+	})
+	suite.Run("Redirected status code should use errorloc302 instead of its error page", func() {
+		contents, err := os.ReadFile(filepath.Join(suite.TempDir, "haproxy.cfg"))
+		if err != nil {
+			suite.T().Error(err.Error())
+		}
+		suite.True(strings.Contains(string(contents), "errorloc302 503 https://status.example.local/"), "expected errorloc302 in haproxy.cfg")
+		suite.False(strings.Contains(string(contents), "http-error status 503"), "unexpected http-error rule of status 503")
+		suite.True(strings.Contains(string(contents), "http-error status 404"), "expected http-error rule of status 404")
+	})
+}
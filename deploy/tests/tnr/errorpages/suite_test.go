@@ -0,0 +1,51 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errorpages
+
+import (
+	"testing"
+
+	"github.com/haproxytech/kubernetes-ingress/deploy/tests/tnr"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/stretchr/testify/suite"
+)
+
+type ErrorPagesSuite struct {
+	tnr.BaseSuite
+}
+
+func TestErrorPages(t *testing.T) {
+	suite.Run(t, new(ErrorPagesSuite))
+}
+
+func (suite *ErrorPagesSuite) ErrorPagesFixture(annotations map[string]string) {
+	suite.StartController()
+	configMaps := []any{}
+	// The same ConfigMap in the namespace of the service and in another namespace.
+	for _, namespace := range []string{tnr.Namespace, "other"} {
+		configMaps = append(configMaps, &store.ConfigMap{
+			Namespace: namespace,
+			Name:      "branded-errors",
+			Annotations: map[string]string{
+				"503": "<html><body>Branded service unavailable</body></html>",
+				"404": "<html><body>Branded not found</body></html>",
+			},
+			Status: store.ADDED,
+		})
+	}
+	service := tnr.NewService("branded-service", "https", 8443, annotations)
+	ingress := tnr.NewIngress("branded-ingress", "branded.example.local", "/", service, nil)
+	suite.Sync(append(configMaps, tnr.NewEndpoints(service, "10.244.0.12"), service, ingress)...)
+}
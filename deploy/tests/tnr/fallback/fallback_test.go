@@ -0,0 +1,35 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fallback
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func (suite *FallbackSuite) TestFallbackService() {
+	suite.FallbackServiceFixture()
+	suite.Run("Fallback service should be used before the host/path routes when the service has no usable server", func() {
+		contents, err := os.ReadFile(filepath.Join(suite.TempDir, "haproxy.cfg"))
+		if err != nil {
+			suite.T().Error(err.Error())
+		}
+		rules := "  use_backend ns_svc_tenant-degraded_http if { var(txn.path_match),field(1,.) -m str ns_svc_tenant-service_https } { nbsrv(ns_svc_tenant-service_https) eq 0 }\n" +
+			"  use_backend %[var(txn.path_match),field(1,.)]\n"
+		c := strings.Count(string(contents), rules)
+		suite.Exactly(2, c, "fallback use_backend should precede the host/path use_backend in http and https frontends")
+	})
+}
@@ -0,0 +1,38 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fallback
+
+import (
+	"testing"
+
+	"github.com/haproxytech/kubernetes-ingress/deploy/tests/tnr"
+	"github.com/stretchr/testify/suite"
+)
+
+type FallbackSuite struct {
+	tnr.BaseSuite
+}
+
+func TestFallback(t *testing.T) {
+	suite.Run(t, new(FallbackSuite))
+}
+
+func (suite *FallbackSuite) FallbackServiceFixture() {
+	suite.StartController()
+	service := tnr.NewService("tenant-service", "https", 8443, map[string]string{"fallback-service": "tenant-degraded:http"})
+	degradedService := tnr.NewService("tenant-degraded", "http", 80, nil)
+	ingress := tnr.NewIngress("tenant-ingress", "tenant.example.local", "/app", service, nil)
+	suite.Sync(service, tnr.NewEndpoints(degradedService, "10.244.0.14"), degradedService, ingress)
+}
@@ -0,0 +1,55 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forwardauth
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func (suite *ForwardAuthSuite) TestForwardAuth() {
+	suite.ForwardAuthFixture(map[string]string{
+		"auth-url":              "http://oauth2-proxy.auth.svc.cluster.local:4180/oauth2/auth",
+		"auth-response-headers": "X-Auth-Request-User, X-Auth-Request-Groups",
+		"auth-signin":           "https://auth.example.local/oauth2/start",
+	})
+	suite.Run("Requests of the ingress should be authenticated by the authentication service", func() {
+		contents, err := os.ReadFile(filepath.Join(suite.TempDir, "haproxy.cfg"))
+		if err != nil {
+			suite.T().Error(err.Error())
+		}
+		cfg := string(contents)
+		lua := filepath.Join(suite.TempDir, "auth-request.lua")
+		suite.Contains(cfg, "lua-load "+lua)
+		_, err = os.Stat(lua)
+		suite.NoError(err, "auth-request Lua script should be written")
+
+		rules := []string{
+			"http-request lua.auth-request http://oauth2-proxy.auth.svc.cluster.local:4180/oauth2/auth X-Auth-Request-User,X-Auth-Request-Groups if { var(txn.path_match) -m dom ",
+			"http-request redirect location https://auth.example.local/oauth2/start?rd=%[ssl_fc,iif(https,http)]://%[req.hdr(host)]%[pathq,url_enc] if { var(txn.path_match) -m dom ",
+			"http-request deny deny_status 401 if { var(txn.path_match) -m dom ",
+			"http-request deny deny_status 403 if { var(txn.path_match) -m dom ",
+			"http-request deny deny_status 500 if { var(txn.path_match) -m dom ",
+		}
+		previous := -1
+		for _, rule := range rules {
+			suite.Exactly(2, strings.Count(cfg, rule), "expected '%s' in http and https frontends", rule)
+			index := strings.Index(cfg, rule)
+			suite.Greater(index, previous, "'%s' should follow the previous rules", rule)
+			previous = index
+		}
+	})
+}
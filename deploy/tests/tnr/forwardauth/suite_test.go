@@ -0,0 +1,37 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forwardauth
+
+import (
+	"testing"
+
+	"github.com/haproxytech/kubernetes-ingress/deploy/tests/tnr"
+	"github.com/stretchr/testify/suite"
+)
+
+type ForwardAuthSuite struct {
+	tnr.BaseSuite
+}
+
+func TestForwardAuth(t *testing.T) {
+	suite.Run(t, new(ForwardAuthSuite))
+}
+
+func (suite *ForwardAuthSuite) ForwardAuthFixture(ingressAnnotations map[string]string) {
+	suite.StartController()
+	service := tnr.NewService("app-service", "https", 8443, nil)
+	ingress := tnr.NewIngress("app-ingress", "app.example.local", "/", service, ingressAnnotations)
+	suite.Sync(tnr.NewEndpoints(service, "10.244.0.12"), service, ingress)
+}
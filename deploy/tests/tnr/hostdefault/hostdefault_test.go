@@ -0,0 +1,45 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hostdefault
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func (suite *HostDefaultSuite) TestHostDefaultBackend() {
+	suite.HostDefaultBackendFixture()
+	suite.Run("Host default backend should be used after the host/path routes for its host only", func() {
+		contents, err := os.ReadFile(filepath.Join(suite.TempDir, "haproxy.cfg"))
+		if err != nil {
+			suite.T().Error(err.Error())
+		}
+		rules := "  use_backend %[var(txn.path_match),field(1,.)] if { var(txn.path_match) -m found }\n" +
+			"  use_backend ns_svc_tenant-404_http if { var(txn.host) -m str tenant.example.local }"
+		c := strings.Count(string(contents), rules)
+		suite.Exactly(2, c, "host default use_backend should follow the host/path use_backend in http and https frontends")
+	})
+}
+
+func (suite *HostDefaultSuite) TestHostDefaultBackendConflict() {
+	suite.HostDefaultBackendConflictFixture()
+	suite.Run("Host default backend set by several ingresses should be the one of the oldest ingress", func() {
+		contents := suite.HaproxyConfig()
+		suite.Exactly(2, strings.Count(contents, "  use_backend ns_svc_tenant-404_http if { var(txn.host) -m str tenant.example.local }"),
+			"the default backend of the older ingress should be used in http and https frontends")
+		suite.NotContains(contents, "use_backend ns_svc_hijack-404_http", "the default backend of the newer ingress should be ignored")
+	})
+}
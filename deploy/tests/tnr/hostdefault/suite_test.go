@@ -0,0 +1,66 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hostdefault
+
+import (
+	"testing"
+	"time"
+
+	"github.com/haproxytech/kubernetes-ingress/deploy/tests/tnr"
+	"github.com/stretchr/testify/suite"
+)
+
+type HostDefaultSuite struct {
+	tnr.BaseSuite
+}
+
+func TestHostDefault(t *testing.T) {
+	suite.Run(t, new(HostDefaultSuite))
+}
+
+func (suite *HostDefaultSuite) HostDefaultBackendFixture() {
+	suite.StartController()
+	service := tnr.NewService("tenant-service", "https", 8443, nil)
+	notFoundService := tnr.NewService("tenant-404", "http", 80, nil)
+	ingress := tnr.NewIngress("tenant-ingress", "tenant.example.local", "/app", service, map[string]string{
+		"host-default-backend": "tenant-404:http",
+	})
+	suite.Sync(
+		tnr.NewEndpoints(service, "10.244.0.13"), service,
+		tnr.NewEndpoints(notFoundService, "10.244.0.14"), notFoundService,
+		ingress,
+	)
+}
+
+func (suite *HostDefaultSuite) HostDefaultBackendConflictFixture() {
+	suite.StartController()
+	service := tnr.NewService("tenant-service", "https", 8443, nil)
+	olderService := tnr.NewService("tenant-404", "http", 80, nil)
+	newerService := tnr.NewService("hijack-404", "http", 80, nil)
+	older := tnr.NewIngress("z-tenant-ingress", "tenant.example.local", "/app", service, map[string]string{
+		"host-default-backend": "tenant-404:http",
+	})
+	older.CreationTime = time.Now().Add(-time.Hour)
+	newer := tnr.NewIngress("a-hijack-ingress", "tenant.example.local", "/other", service, map[string]string{
+		"host-default-backend": "hijack-404:http",
+	})
+	newer.CreationTime = time.Now()
+	suite.Sync(
+		tnr.NewEndpoints(service, "10.244.0.13"), service,
+		tnr.NewEndpoints(olderService, "10.244.0.14"), olderService,
+		tnr.NewEndpoints(newerService, "10.244.0.15"), newerService,
+		newer, older,
+	)
+}
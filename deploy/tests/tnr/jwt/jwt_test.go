@@ -0,0 +1,87 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func (suite *JWTSuite) TestJWT() {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+	b64 := base64.RawURLEncoding.EncodeToString
+	jwks := fmt.Sprintf(`{"keys":[{"kty":"EC","kid":"key-1","use":"sig","crv":"P-256","x":"%s","y":"%s"}]}`,
+		b64(key.X.FillBytes(make([]byte, 32))), b64(key.Y.FillBytes(make([]byte, 32))))
+
+	suite.JWTFixture(map[string]string{
+		"jwt-secret":        "jwt-keys",
+		"jwt-algorithms":    "ES256",
+		"jwt-issuer":        "https://issuer.example.local",
+		"jwt-audience":      "app",
+		"jwt-claim-headers": "sub X-User, groups X-Groups",
+	}, map[string][]byte{
+		"jwks.json": []byte(jwks),
+		"tls.crt":   pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}),
+	})
+	suite.Run("Requests of the ingress should carry a valid JWT", func() {
+		contents, err := os.ReadFile(filepath.Join(suite.TempDir, "haproxy.cfg"))
+		if err != nil {
+			suite.T().Error(err.Error())
+		}
+		cfg := string(contents)
+		jwksKey := filepath.Join(suite.TempDir, "certs", "jwt", "ns_jwt-keys_key-1.pem")
+		pemKey := filepath.Join(suite.TempDir, "certs", "jwt", "ns_jwt-keys_tls_0.pem")
+		for _, keyFile := range []string{jwksKey, pemKey} {
+			_, err = os.Stat(keyFile)
+			suite.NoError(err, "JWT key file should be written")
+		}
+
+		rules := []string{
+			"http-request set-var(txn.jwt_bearer) http_auth_bearer if { var(txn.path_match) -m dom ",
+			"} !{ var(txn.jwt_alg) -m str ES256 }",
+			"} !{ var(txn.jwt_verified) -m bool } { var(txn.jwt_bearer),jwt_header_query('$.kid') -m str key-1 } { var(txn.jwt_bearer),jwt_verify(txn.jwt_alg,\"" + jwksKey + "\") -m int 1 }",
+			"} !{ var(txn.jwt_verified) -m bool } { var(txn.jwt_bearer),jwt_verify(txn.jwt_alg,\"" + pemKey + "\") -m int 1 }",
+			"} !{ var(txn.jwt_verified) -m bool }\n",
+			"!{ var(txn.jwt_bearer),jwt_payload_query('$.exp','int'),sub(txn.now) -m int gt 0 }",
+			"!{ var(txn.jwt_bearer),jwt_payload_query('$.iss') -m str https://issuer.example.local }",
+			`!{ var(txn.jwt_bearer),jwt_payload_query('$.aud') -m str app } !{ var(txn.jwt_bearer),jwt_payload_query('$.aud') -m reg '^\[(.*,)?[[:space:]]*"app"[[:space:]]*(,.*)?\]$' }`,
+			"http-request del-header X-User if { var(txn.path_match) -m dom ",
+			"http-request set-header X-User %[var(txn.jwt_bearer),jwt_payload_query('$.sub')] if { var(txn.path_match) -m dom ",
+			"http-request set-header X-Groups %[var(txn.jwt_bearer),jwt_payload_query('$.groups')] if { var(txn.path_match) -m dom ",
+		}
+		previous := -1
+		for _, rule := range rules {
+			suite.Exactly(2, strings.Count(cfg, rule), "expected '%s' in http and https frontends", rule)
+			index := strings.Index(cfg, rule)
+			suite.Greater(index, previous, "'%s' should follow the previous rules", rule)
+			previous = index
+		}
+	})
+}
@@ -0,0 +1,78 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+)
+
+func (suite *MaintenanceSuite) TestMaintenance() {
+	shopIngress := suite.MaintenanceFixture(map[string]string{
+		"maintenance":               "true",
+		"maintenance-page":          "maintenance-pages",
+		"maintenance-retry-after":   "120",
+		"maintenance-allow-list":    "10.0.0.0/8, 192.168.1.1",
+		"maintenance-bypass-header": "X-Maintenance-Bypass secret",
+	})
+	mapFile := filepath.Join(suite.TempDir, "maps", "maintenance.map")
+	suite.Run("Requests of the ingress in maintenance should get the maintenance page", func() {
+		cfg := suite.HaproxyConfig()
+		for _, expected := range []string{
+			`http-request return status 503 content-type "text/html" string "<html><body>Down for maintenance</body></html>" hdr Retry-After "120" if { var(txn.path_match) -m dom `,
+			`{ str(ns/shop-ingress),map(` + mapFile + `) -m found } !{ src 10.0.0.0/8 192.168.1.1 } !{ req.hdr(X-Maintenance-Bypass) -m str secret }`,
+		} {
+			suite.True(strings.Contains(cfg, expected), "expected '%s' in haproxy.cfg", expected)
+		}
+		contents, err := os.ReadFile(mapFile)
+		if err != nil {
+			suite.T().Error(err.Error())
+		}
+		suite.Contains(string(contents), "ns/shop-ingress")
+	})
+
+	suite.Run("Disabling the maintenance should only update the map", func() {
+		ingress := *shopIngress
+		ingress.Annotations = map[string]string{}
+		for k, v := range shopIngress.Annotations {
+			ingress.Annotations[k] = v
+		}
+		ingress.Annotations["maintenance"] = "false"
+		ingress.Status = store.MODIFIED
+		suite.Sync(&ingress)
+
+		suite.Contains(suite.HaproxyConfig(), "{ str(ns/shop-ingress),map("+mapFile+") -m found }")
+		contents, err := os.ReadFile(mapFile)
+		if err != nil {
+			suite.T().Error(err.Error())
+		}
+		suite.NotContains(string(contents), "ns/shop-ingress")
+	})
+}
+
+func (suite *MaintenanceSuite) TestMaintenanceInvalidBypassHeader() {
+	suite.MaintenanceFixture(map[string]string{
+		"maintenance":               "true",
+		"maintenance-bypass-header": "X-Bypass}{ secret",
+	})
+	suite.Run("Bypass header with an invalid name should be ignored", func() {
+		cfg := suite.HaproxyConfig()
+		suite.Contains(cfg, "http-request return status 503")
+		suite.NotContains(cfg, "req.hdr(X-Bypass")
+	})
+}
@@ -0,0 +1,47 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"testing"
+
+	"github.com/haproxytech/kubernetes-ingress/deploy/tests/tnr"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/stretchr/testify/suite"
+)
+
+type MaintenanceSuite struct {
+	tnr.BaseSuite
+}
+
+func TestMaintenance(t *testing.T) {
+	suite.Run(t, new(MaintenanceSuite))
+}
+
+func (suite *MaintenanceSuite) MaintenanceFixture(ingressAnnotations map[string]string) *store.Ingress {
+	suite.StartController()
+	service := tnr.NewService("shop-service", "https", 8443, nil)
+	configMap := &store.ConfigMap{
+		Namespace: tnr.Namespace,
+		Name:      "maintenance-pages",
+		Annotations: map[string]string{
+			"503": "<html><body>Down for maintenance</body></html>",
+		},
+		Status: store.ADDED,
+	}
+	ingress := tnr.NewIngress("shop-ingress", "shop.example.local", "/", service, ingressAnnotations)
+	suite.Sync(tnr.NewEndpoints(service, "10.244.0.12"), configMap, service, ingress)
+	return ingress
+}
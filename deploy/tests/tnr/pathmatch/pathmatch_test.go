@@ -0,0 +1,37 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathmatch
+
+import (
+	"os"
+	"path/filepath"
+)
+
+func (suite *PathMatchSuite) TestPathMatchNotMerged() {
+	suite.MergedIngressesFixture()
+	suite.Run("path-match should only apply to the paths of its ingress when ingresses of a service are merged", func() {
+		regexMap, err := os.ReadFile(filepath.Join(suite.TempDir, "maps", "path-regex.map"))
+		if err != nil {
+			suite.T().Error(err.Error())
+		}
+		suite.Contains(string(regexMap), "/api/v[0-9]+")
+		suite.NotContains(string(regexMap), "/static")
+		prefixMap, err := os.ReadFile(filepath.Join(suite.TempDir, "maps", "path-prefix.map"))
+		if err != nil {
+			suite.T().Error(err.Error())
+		}
+		suite.Contains(string(prefixMap), "app.example.local/static")
+	})
+}
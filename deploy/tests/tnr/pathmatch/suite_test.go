@@ -0,0 +1,48 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathmatch
+
+import (
+	"testing"
+
+	"github.com/haproxytech/kubernetes-ingress/deploy/tests/tnr"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/stretchr/testify/suite"
+)
+
+type PathMatchSuite struct {
+	tnr.BaseSuite
+}
+
+func TestPathMatch(t *testing.T) {
+	suite.Run(t, new(PathMatchSuite))
+}
+
+// MergedIngressesFixture sends two ingresses of the same service, merged by the controller,
+// with the path-match annotation set on the first one only.
+func (suite *PathMatchSuite) MergedIngressesFixture() {
+	suite.StartController("--experimental=use-ingress-merge")
+	service := tnr.NewService("app-service", "https", 8443, nil)
+	regexIngress := tnr.NewIngress("regex-ingress", "app.example.local", "/api/v[0-9]+", service, map[string]string{
+		"path-match": "regex",
+	})
+	prefixIngress := tnr.NewIngress("prefix-ingress", "app.example.local", "/static", service, nil)
+	for _, ingress := range []*store.Ingress{regexIngress, prefixIngress} {
+		for _, path := range ingress.Rules["app.example.local"].Paths {
+			path.PathTypeMatch = store.PATH_TYPE_IMPLEMENTATION_SPECIFIC
+		}
+	}
+	suite.Sync(tnr.NewEndpoints(service, "10.244.0.12"), service, regexIngress, prefixIngress)
+}
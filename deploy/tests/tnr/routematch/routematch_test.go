@@ -0,0 +1,68 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routematch
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+func (suite *RouteMatchSuite) TestRouteMatch() {
+	suite.RouteMatchFixture()
+	suite.Run("Route match annotations should create named ACLs used by the backend switching rule", func() {
+		contents, err := os.ReadFile(filepath.Join(suite.TempDir, "haproxy.cfg"))
+		if err != nil {
+			suite.T().Error(err.Error())
+		}
+		acls := map[string]string{
+			"header":      `req.fhdr\(X-Env\) -m str staging`,
+			"headerRegex": `req.fhdr\(X-Version\) -m reg \^v2\\\.`,
+			"query":       `urlp\(debug\) -m str true`,
+			"method":      `method GET POST`,
+			"src":         `src 10\.0\.0\.0/8`,
+		}
+		names := map[string]string{}
+		for criterion, acl := range acls {
+			matches := regexp.MustCompile(`acl (match_\w+) `+acl).FindAllStringSubmatch(string(contents), -1)
+			suite.Len(matches, 2, "acl for %s should be in both http and https frontends", criterion)
+			if len(matches) != 0 {
+				names[criterion] = matches[0][1]
+			}
+		}
+		useBackend := regexp.MustCompile(`use_backend ns_svc_match-service_https if { var\(txn.host\) -m str match.example.local } { path -m beg / } (.*)`).
+			FindAllStringSubmatch(string(contents), -1)
+		suite.Len(useBackend, 2, "use_backend should be in both http and https frontends")
+		for _, rule := range useBackend {
+			for criterion, name := range names {
+				suite.Regexp(`(^| )`+name+`( |$)`, rule[1], "use_backend condition should reference the %s acl", criterion)
+			}
+		}
+	})
+}
+
+func (suite *RouteMatchSuite) TestRouteMatchAuth() {
+	suite.RouteMatchAuthFixture()
+	suite.Run("Rules of a route-match ingress should apply to the requests of its route", func() {
+		contents := suite.HaproxyConfig()
+		auth := regexp.MustCompile(`http-request auth realm Protected-Content if { var\(txn.path_match\) -m dom (\w+) } `).FindAllStringSubmatch(contents, -1)
+		suite.Require().Len(auth, 2, "auth rule should be in both http and https frontends")
+		setPathMatch := regexp.MustCompile(`http-request set-var\(txn.path_match\) str\(ns_svc_match-service_https\.(\w+)\) if { var\(txn.host\) -m str match.example.local } { path -m beg / } match_req_fhdr_\w+ !{ var\(txn.custom_route\) -m found }`).
+			FindAllStringSubmatch(contents, -1)
+		suite.Require().Len(setPathMatch, 2, "path match of the route should be set in both http and https frontends")
+		suite.Equal(auth[0][1], setPathMatch[0][1], "path match of the route should hold the id of the auth rule")
+		suite.Contains(contents, "http-request set-var(txn.custom_route) bool(true) if { var(txn.host) -m str match.example.local } { path -m beg / } match_req_fhdr_")
+	})
+}
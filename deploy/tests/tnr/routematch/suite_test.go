@@ -0,0 +1,61 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routematch
+
+import (
+	"testing"
+
+	"github.com/haproxytech/kubernetes-ingress/deploy/tests/tnr"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/stretchr/testify/suite"
+)
+
+type RouteMatchSuite struct {
+	tnr.BaseSuite
+}
+
+func TestRouteMatch(t *testing.T) {
+	suite.Run(t, new(RouteMatchSuite))
+}
+
+func (suite *RouteMatchSuite) RouteMatchFixture() {
+	suite.StartController()
+	service := tnr.NewService("match-service", "https", 8443, nil)
+	ingress := tnr.NewIngress("match-ingress", "match.example.local", "/", service, map[string]string{
+		"route-match-headers":      "X-Env staging\nX-Version regex ^v2\\.",
+		"route-match-query-params": "debug true",
+		"route-match-methods":      "GET, POST",
+		"route-match-src":          "10.0.0.0/8",
+	})
+	suite.Sync(tnr.NewEndpoints(service, "10.244.0.12"), service, ingress)
+}
+
+// RouteMatchAuthFixture protects with basic auth an ingress routed on a header only.
+func (suite *RouteMatchSuite) RouteMatchAuthFixture() {
+	suite.StartController()
+	service := tnr.NewService("match-service", "https", 8443, nil)
+	secret := &store.Secret{
+		Namespace: tnr.Namespace,
+		Name:      "match-users",
+		Data:      map[string][]byte{"admin": []byte("$5$salt$hash")},
+		Status:    store.ADDED,
+	}
+	ingress := tnr.NewIngress("match-ingress", "match.example.local", "/", service, map[string]string{
+		"route-match-headers": "X-Env staging",
+		"auth-type":           "basic-auth",
+		"auth-secret":         "match-users",
+	})
+	suite.Sync(tnr.NewEndpoints(service, "10.244.0.12"), secret, service, ingress)
+}
@@ -0,0 +1,79 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package settimeout
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const backendRules = "  http-request set-timeout server var(txn.set_timeout_server) if { var(txn.set_timeout_server) -m found }\n" +
+	"  http-request set-timeout tunnel var(txn.set_timeout_tunnel) if { var(txn.set_timeout_tunnel) -m found }\n"
+
+// backendSection returns the content of the backend section of the haproxy configuration.
+func backendSection(config, name string) string {
+	_, section, _ := strings.Cut(config, "\nbackend "+name+" ")
+	if end := strings.Index(section, "\n\n"); end != -1 {
+		section = section[:end]
+	}
+	return section + "\n"
+}
+
+func (suite *SetTimeoutSuite) TestSetTimeout() {
+	suite.SetTimeoutFixture(map[string]string{
+		"set-timeout-server": "5m",
+		"set-timeout-tunnel": "1h",
+	})
+	suite.Run("Timeouts of the ingress should be set in the frontends and applied in the backend", func() {
+		contents := suite.HaproxyConfig()
+		for _, rule := range []string{
+			"http-request set-var(txn.set_timeout_server) int(300000) if { var(txn.path_match) -m dom ",
+			"http-request set-var(txn.set_timeout_tunnel) int(3600000) if { var(txn.path_match) -m dom ",
+		} {
+			c := strings.Count(contents, rule)
+			suite.Exactly(2, c, "expected '%s' in http and https frontends", rule)
+		}
+		suite.Exactly(2, strings.Count(contents, "set-timeout "), "set-timeout should only be used in the backend")
+		suite.Contains(backendSection(contents, "ns_svc_export-service_https"), backendRules)
+	})
+}
+
+func (suite *SetTimeoutSuite) TestSetTimeoutPerPath() {
+	suite.SetTimeoutFixture(map[string]string{
+		"set-timeout-server": "/export 10m",
+	})
+	suite.Run("Timeout of a path should only be set for the requests of this path", func() {
+		contents := suite.HaproxyConfig()
+		ruleID := regexp.MustCompile(`http-request set-var\(txn.set_timeout_server\) int\(600000\) if \{ var\(txn.path_match\) -m dom (\S+) \}`).FindStringSubmatch(contents)
+		if !suite.NotNil(ruleID, "expected the set-var rule of the path timeout") {
+			return
+		}
+		prefixMap, err := os.ReadFile(filepath.Join(suite.TempDir, "maps", "path-prefix.map"))
+		if err != nil {
+			suite.T().Fatal(err.Error())
+		}
+		for _, line := range strings.Split(string(prefixMap), "\n") {
+			switch {
+			case strings.HasPrefix(line, "export.example.local/export/"):
+				suite.Contains(line, ruleID[1], "the route of '/export' should hold the timeout rule")
+			case strings.HasPrefix(line, "export.example.local/api/"):
+				suite.NotContains(line, ruleID[1], "the route of '/api' should not hold the timeout rule")
+			}
+		}
+		suite.Contains(backendSection(contents, "ns_svc_export-service_https"), backendRules)
+	})
+}
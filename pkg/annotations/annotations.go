@@ -29,7 +29,7 @@ type Annotations interface {
 	Global(g *models.Global, l *models.LogTargets) []Annotation
 	Defaults(d *models.Defaults) []Annotation
 	Backend(b *models.Backend, s store.K8s, c certs.Certificates) []Annotation
-	Frontend(i *store.Ingress, r *rules.List, m maps.Maps) []Annotation
+	Frontend(i *store.Ingress, r *rules.List, m maps.Maps, c certs.Certificates) []Annotation
 	Secret(name, defaultNs string, k store.K8s, annotations ...map[string]string) (secret *store.Secret, err error)
 	Timeout(name string, annotations ...map[string]string) (out *int64, err error)
 	String(name string, annotations ...map[string]string) string
@@ -106,22 +106,28 @@ func (a annImpl) Defaults(d *models.Defaults) []Annotation {
 	}
 }
 
-func (a annImpl) Frontend(i *store.Ingress, r *rules.List, m maps.Maps) []Annotation {
+func (a annImpl) Frontend(i *store.Ingress, r *rules.List, m maps.Maps, c certs.Certificates) []Annotation {
 	reqRateLimit := ingress.NewReqRateLimit(r, m)
 	httpsRedirect := ingress.NewHTTPSRedirect(r, i)
 	hostRedirect := ingress.NewHostRedirect(r)
 	reqAuth := ingress.NewReqAuth(r, i)
+	forwardAuth := ingress.NewForwardAuth(r)
+	jwtVerify := ingress.NewJWTVerify(r, i, c)
 	reqCapture := ingress.NewReqCapture(r)
 	resSetCORS := ingress.NewResSetCORS(r)
+	maintenance := ingress.NewMaintenance(r, i, m)
 	return []Annotation{
 		// Simple annoations
 		ingress.NewDenyList("deny-list", r, m),
 		ingress.NewAllowList("allow-list", r, m),
 		ingress.NewSrcIPHdr("src-ip-header", r),
 		ingress.NewReqSetHost("set-host", r),
+		ingress.NewReqSetTimeout("set-timeout-server", models.HTTPRequestRuleTimeoutTypeServer, r, i),
+		ingress.NewReqSetTimeout("set-timeout-tunnel", models.HTTPRequestRuleTimeoutTypeTunnel, r, i),
 		ingress.NewReqPathRewrite("path-rewrite", r),
 		ingress.NewReqSetHdr("request-set-header", r),
 		ingress.NewResSetHdr("response-set-header", r),
+		ingress.NewClientCrtHeaders("client-crt-headers", r),
 		// Annotation factory for related annotations
 		httpsRedirect.NewAnnotation("ssl-redirect"),
 		httpsRedirect.NewAnnotation("ssl-redirect-port"),
@@ -136,8 +142,23 @@ func (a annImpl) Frontend(i *store.Ingress, r *rules.List, m maps.Maps) []Annota
 		reqAuth.NewAnnotation("auth-type"),
 		reqAuth.NewAnnotation("auth-realm"),
 		reqAuth.NewAnnotation("auth-secret"),
+		forwardAuth.NewAnnotation("auth-url"),
+		forwardAuth.NewAnnotation("auth-response-headers"),
+		forwardAuth.NewAnnotation("auth-signin"),
+		jwtVerify.NewAnnotation("jwt-secret"),
+		jwtVerify.NewAnnotation("jwt-algorithms"),
+		jwtVerify.NewAnnotation("jwt-issuer"),
+		jwtVerify.NewAnnotation("jwt-audience"),
+		jwtVerify.NewAnnotation("jwt-claim-headers"),
 		reqCapture.NewAnnotation("request-capture"),
 		reqCapture.NewAnnotation("request-capture-len"),
+		// maintenance-status must be processed before maintenance-page
+		maintenance.NewAnnotation("maintenance"),
+		maintenance.NewAnnotation("maintenance-status"),
+		maintenance.NewAnnotation("maintenance-page"),
+		maintenance.NewAnnotation("maintenance-retry-after"),
+		maintenance.NewAnnotation("maintenance-allow-list"),
+		maintenance.NewAnnotation("maintenance-bypass-header"),
 		// always put cors-enable annotation before any oth
 		resSetCORS.NewAnnotation("cors-enable"),
 		resSetCORS.NewAnnotation("cors-allow-origin"),
@@ -173,6 +194,7 @@ func (a annImpl) Backend(b *models.Backend, s store.K8s, c certs.Certificates) [
 			annotations,
 			service.NewCheckHTTP("check-http", b),
 			service.NewForwardedFor("forwarded-for", b),
+			service.NewErrorPagesRedirect("error-pages-redirect", b),
 		)
 	}
 	return annotations
@@ -256,36 +278,68 @@ func Timeout(name string, annotations ...map[string]string) (out *int64, err err
 // SpecificAnnotations is a set of annotations that uses rules to produce specific configuration with rule ID in configuration file.
 // These annotations in an ingress can't be merged with other ingresses annotations when these ingresses point to the same service because specific paths must be treated specifically.
 var SpecificAnnotations = map[string]struct{}{
-	"backend-config-snippet":  {},
-	"deny-list":               {},
-	"blacklist":               {},
-	"allow-list":              {},
-	"whitelist":               {},
-	"src-ip-header":           {},
-	"auth-type":               {},
-	"auth-realm":              {},
-	"auth-secret":             {},
-	"ssl-redirect":            {},
-	"ssl-redirect-port":       {},
-	"ssl-redirect-code":       {},
-	"request-redirect":        {},
-	"request-redirect-code":   {},
-	"request-capture":         {},
-	"request-capture-len":     {},
-	"path-rewrite":            {},
-	"rate-limit-requests":     {},
-	"rate-limit-period":       {},
-	"rate-limit-size":         {},
-	"rate-limit-status-code":  {},
-	"rate-limit-whitelist":    {},
-	"request-set-header":      {},
-	"response-set-header":     {},
-	"set-host":                {},
-	"cors-enable":             {},
-	"cors-allow-origin":       {},
-	"cors-allow-methods":      {},
-	"cors-allow-headers":      {},
-	"cors-max-age":            {},
-	"cors-allow-credentials":  {},
-	"cors-respond-to-options": {},
+	"backend-config-snippet":    {},
+	"deny-list":                 {},
+	"blacklist":                 {},
+	"allow-list":                {},
+	"whitelist":                 {},
+	"src-ip-header":             {},
+	"auth-type":                 {},
+	"auth-realm":                {},
+	"auth-secret":               {},
+	"auth-url":                  {},
+	"auth-response-headers":     {},
+	"auth-signin":               {},
+	"jwt-secret":                {},
+	"jwt-algorithms":            {},
+	"jwt-issuer":                {},
+	"jwt-audience":              {},
+	"jwt-claim-headers":         {},
+	"client-ca":                 {},
+	"client-crt-optional":       {},
+	"client-crl":                {},
+	"client-crt-headers":        {},
+	"ssl-redirect":              {},
+	"ssl-redirect-port":         {},
+	"ssl-redirect-code":         {},
+	"request-redirect":          {},
+	"request-redirect-code":     {},
+	"request-capture":           {},
+	"request-capture-len":       {},
+	"path-rewrite":              {},
+	"path-match":                {},
+	"rate-limit-requests":       {},
+	"rate-limit-period":         {},
+	"rate-limit-size":           {},
+	"rate-limit-status-code":    {},
+	"rate-limit-whitelist":      {},
+	"request-set-header":        {},
+	"response-set-header":       {},
+	"set-host":                  {},
+	"set-timeout-server":        {},
+	"set-timeout-tunnel":        {},
+	"cors-enable":               {},
+	"cors-allow-origin":         {},
+	"cors-allow-methods":        {},
+	"cors-allow-headers":        {},
+	"cors-max-age":              {},
+	"cors-allow-credentials":    {},
+	"cors-respond-to-options":   {},
+	"canary":                    {},
+	"canary-by-header":          {},
+	"canary-by-header-value":    {},
+	"canary-by-cookie":          {},
+	"canary-weight":             {},
+	"route-match-headers":       {},
+	"route-match-methods":       {},
+	"route-match-query-params":  {},
+	"route-match-src":           {},
+	"route-priority":            {},
+	"host-default-backend":      {},
+	"maintenance":               {},
+	"maintenance-status":        {},
+	"maintenance-page":          {},
+	"maintenance-retry-after":   {},
+	"maintenance-allow-list":    {},
+	"maintenance-bypass-header": {},
 }
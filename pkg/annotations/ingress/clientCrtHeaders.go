@@ -0,0 +1,58 @@
+package ingress
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations/common"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/rules"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+)
+
+type ClientCrtHeaders struct {
+	rules *rules.List
+	name  string
+}
+
+func NewClientCrtHeaders(n string, r *rules.List) *ClientCrtHeaders {
+	return &ClientCrtHeaders{name: n, rules: r}
+}
+
+func (a *ClientCrtHeaders) GetName() string {
+	return a.name
+}
+
+// Process parses a list of "<field> [<header name>]" items, the default header name of the field
+// being used when omitted.
+func (a *ClientCrtHeaders) Process(k store.K8s, annotations ...map[string]string) (err error) {
+	input := common.GetValue(a.GetName(), annotations...)
+	if input == "" {
+		return err
+	}
+	rule := &rules.ReqClientCertHeaders{}
+	headers := map[string]struct{}{}
+	for _, item := range strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == '\n' }) {
+		fields := strings.Fields(item)
+		if len(fields) == 0 {
+			continue
+		}
+		field, ok := rules.ClientCertFields[fields[0]]
+		if !ok || len(fields) > 2 {
+			return fmt.Errorf("invalid value '%s', expected '<field> [<header name>]'", item)
+		}
+		header := rules.ClientCertHeader{Field: fields[0], Header: field.Header}
+		if len(fields) == 2 {
+			if !headerNameRegex.MatchString(fields[1]) {
+				return fmt.Errorf("invalid header name '%s'", fields[1])
+			}
+			header.Header = fields[1]
+		}
+		if _, ok = headers[strings.ToLower(header.Header)]; ok {
+			return fmt.Errorf("duplicate header '%s'", header.Header)
+		}
+		headers[strings.ToLower(header.Header)] = struct{}{}
+		rule.Headers = append(rule.Headers, header)
+	}
+	a.rules.Add(rule)
+	return err
+}
@@ -0,0 +1,90 @@
+package ingress
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations/common"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/rules"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+)
+
+// headerNameRegex matches valid HTTP header names.
+var headerNameRegex = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$")
+
+type ForwardAuth struct {
+	authRule *rules.ReqForwardAuth
+	rules    *rules.List
+}
+
+type ForwardAuthAnn struct {
+	parent *ForwardAuth
+	name   string
+}
+
+func NewForwardAuth(r *rules.List) *ForwardAuth {
+	return &ForwardAuth{rules: r}
+}
+
+func (p *ForwardAuth) NewAnnotation(n string) ForwardAuthAnn {
+	return ForwardAuthAnn{name: n, parent: p}
+}
+
+func (a ForwardAuthAnn) GetName() string {
+	return a.name
+}
+
+func (a ForwardAuthAnn) Process(k store.K8s, annotations ...map[string]string) (err error) {
+	input := common.GetValue(a.GetName(), annotations...)
+	if input == "" {
+		return err
+	}
+	if a.name != "auth-url" && a.parent.authRule == nil {
+		return err
+	}
+
+	switch a.name {
+	case "auth-url":
+		if err = checkAuthURL(input); err != nil {
+			return err
+		}
+		a.parent.authRule = &rules.ReqForwardAuth{URL: input}
+		a.parent.rules.Add(a.parent.authRule)
+	case "auth-response-headers":
+		headers := strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == ' ' })
+		for _, header := range headers {
+			if !headerNameRegex.MatchString(header) {
+				return fmt.Errorf("invalid header name '%s'", header)
+			}
+		}
+		a.parent.authRule.ResponseHeaders = headers
+	case "auth-signin":
+		if err = checkAuthURL(input); err != nil {
+			return err
+		}
+		if strings.Contains(input, "%") {
+			return fmt.Errorf("unsupported character '%%' in '%s'", input)
+		}
+		a.parent.authRule.SignIn = input
+	default:
+		err = fmt.Errorf("unknown forward auth annotation '%s'", a.name)
+	}
+	return err
+}
+
+// checkAuthURL returns an error if the value is not an absolute http or https URL usable in HAProxy configuration.
+func checkAuthURL(value string) error {
+	if strings.ContainsAny(value, " \t\n\r\"'\\#") {
+		return fmt.Errorf("unsupported characters in URL '%s'", value)
+	}
+	u, err := url.Parse(value)
+	if err != nil {
+		return fmt.Errorf("invalid URL '%s': %w", value, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid URL '%s', expected an absolute http or https URL", value)
+	}
+	return nil
+}
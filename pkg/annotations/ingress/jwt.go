@@ -0,0 +1,126 @@
+package ingress
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations/common"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/certs"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/rules"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+)
+
+// jwtAlgorithms are the signature algorithms of the JWTs verified with public keys.
+var jwtAlgorithms = []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "PS256", "PS384", "PS512"}
+
+var (
+	// jwtClaimRegex matches claim names, nested claims being separated by dots.
+	jwtClaimRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+(\.[A-Za-z0-9_-]+)*$`)
+	// jwtValueRegex matches the values compared to claims and key IDs usable in HAProxy configuration.
+	jwtValueRegex = regexp.MustCompile(`^[^\s"'\\#{}]+$`)
+)
+
+type JWTVerify struct {
+	verifyRule *rules.ReqJWTVerify
+	rules      *rules.List
+	ingress    *store.Ingress
+	certs      certs.Certificates
+}
+
+type JWTVerifyAnn struct {
+	parent *JWTVerify
+	name   string
+}
+
+func NewJWTVerify(r *rules.List, i *store.Ingress, c certs.Certificates) *JWTVerify {
+	return &JWTVerify{rules: r, ingress: i, certs: c}
+}
+
+func (p *JWTVerify) NewAnnotation(n string) JWTVerifyAnn {
+	return JWTVerifyAnn{name: n, parent: p}
+}
+
+func (a JWTVerifyAnn) GetName() string {
+	return a.name
+}
+
+func (a JWTVerifyAnn) Process(k store.K8s, annotations ...map[string]string) (err error) {
+	input := common.GetValue(a.GetName(), annotations...)
+	if input == "" {
+		return err
+	}
+	if a.name != "jwt-secret" && a.parent.verifyRule == nil {
+		return err
+	}
+
+	switch a.name {
+	case "jwt-secret":
+		ns, name, errAnn := common.GetK8sPath(a.name, annotations...)
+		if errAnn != nil {
+			return errAnn
+		}
+		if ns == "" {
+			if a.parent.ingress == nil {
+				return fmt.Errorf("invalid value '%s', expected '<namespace>/<secret>'", input)
+			}
+			ns = a.parent.ingress.Namespace
+		}
+		secret, errSecret := k.GetSecret(ns, name)
+		if errSecret != nil {
+			return errSecret
+		}
+		keys, errKeys := a.parent.certs.AddJWTKeys(secret)
+		if errKeys != nil {
+			return errKeys
+		}
+		a.parent.verifyRule = &rules.ReqJWTVerify{Algorithms: jwtAlgorithms}
+		for _, key := range keys {
+			kid := key.Kid
+			if !jwtValueRegex.MatchString(kid) {
+				// The key is tried for all the tokens
+				kid = ""
+			}
+			a.parent.verifyRule.Keys = append(a.parent.verifyRule.Keys, rules.JWTKey{Kid: kid, Path: key.Path})
+		}
+		a.parent.rules.Add(a.parent.verifyRule)
+	case "jwt-algorithms":
+		algorithms := strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == ' ' })
+		for _, algorithm := range algorithms {
+			supported := false
+			for _, jwtAlgorithm := range jwtAlgorithms {
+				supported = supported || algorithm == jwtAlgorithm
+			}
+			if !supported {
+				return fmt.Errorf("unsupported algorithm '%s', expected one of %s", algorithm, strings.Join(jwtAlgorithms, ", "))
+			}
+		}
+		a.parent.verifyRule.Algorithms = algorithms
+	case "jwt-issuer":
+		if !jwtValueRegex.MatchString(input) {
+			return fmt.Errorf("unsupported characters in issuer '%s'", input)
+		}
+		a.parent.verifyRule.Issuer = input
+	case "jwt-audience":
+		if !jwtValueRegex.MatchString(input) {
+			return fmt.Errorf("unsupported characters in audience '%s'", input)
+		}
+		a.parent.verifyRule.Audience = input
+	case "jwt-claim-headers":
+		var claimHeaders []rules.JWTClaimHeader
+		for _, item := range strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == '\n' }) {
+			fields := strings.Fields(item)
+			if len(fields) == 0 {
+				continue
+			}
+			if len(fields) != 2 || !jwtClaimRegex.MatchString(fields[0]) || !headerNameRegex.MatchString(fields[1]) {
+				return fmt.Errorf("invalid value '%s', expected '<claim> <header name>'", item)
+			}
+			claimHeaders = append(claimHeaders, rules.JWTClaimHeader{Claim: fields[0], Header: fields[1]})
+		}
+		a.parent.verifyRule.ClaimHeaders = claimHeaders
+	default:
+		err = fmt.Errorf("unknown JWT annotation '%s'", a.name)
+	}
+	return err
+}
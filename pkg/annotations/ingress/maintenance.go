@@ -0,0 +1,132 @@
+package ingress
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations/common"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/maps"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/rules"
+	"github.com/haproxytech/kubernetes-ingress/pkg/route"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+// Maintenance returns a static response to the requests of the ingress while it is in maintenance.
+// The rule is kept while the maintenance annotation is set and only the entry of the ingress
+// in the maintenance map is toggled, so that switching the maintenance on and off does not need a reload.
+type Maintenance struct {
+	rule    *rules.ReqReturn
+	rules   *rules.List
+	maps    maps.Maps
+	ingress *store.Ingress
+	bypass  []string
+}
+
+type MaintenanceAnn struct {
+	parent *Maintenance
+	name   string
+}
+
+func NewMaintenance(r *rules.List, i *store.Ingress, m maps.Maps) *Maintenance {
+	return &Maintenance{rules: r, ingress: i, maps: m}
+}
+
+func (p *Maintenance) NewAnnotation(n string) MaintenanceAnn {
+	return MaintenanceAnn{
+		name:   n,
+		parent: p,
+	}
+}
+
+func (a MaintenanceAnn) GetName() string {
+	return a.name
+}
+
+func (a MaintenanceAnn) Process(k store.K8s, annotations ...map[string]string) (err error) {
+	input := common.GetValue(a.GetName(), annotations...)
+	if input == "" || a.parent.ingress == nil {
+		return err
+	}
+	if a.name != "maintenance" && a.parent.rule == nil {
+		return err
+	}
+
+	switch a.name {
+	case "maintenance":
+		var enabled bool
+		enabled, err = utils.GetBoolValue(input, a.name)
+		if err != nil {
+			return err
+		}
+		key := a.parent.ingress.Namespace + "/" + a.parent.ingress.Name
+		if enabled {
+			a.parent.maps.MapAppend(route.MAINTENANCE, key+"\t\t\ton")
+		}
+		a.parent.rule = &rules.ReqReturn{
+			StatusCode: 503,
+			CondTest:   fmt.Sprintf("{ str(%s),map(%s) -m found }", key, maps.GetPath(route.MAINTENANCE)),
+		}
+		a.parent.rules.Add(a.parent.rule)
+	case "maintenance-status":
+		var code int64
+		code, err = strconv.ParseInt(input, 10, 64)
+		if err != nil || code < 200 || code > 599 {
+			return fmt.Errorf("invalid status code '%s'", input)
+		}
+		a.parent.rule.StatusCode = code
+	case "maintenance-page":
+		ns, name, errPath := common.GetK8sPath(a.name, annotations...)
+		if errPath != nil || name == "" {
+			return fmt.Errorf("invalid value '%s', expected '[<namespace>/]<configmap>'", input)
+		}
+		if ns == "" {
+			ns = a.parent.ingress.Namespace
+		}
+		// Only the controller ConfigMap can reference a ConfigMap of another namespace.
+		if a.parent.ingress.Annotations[a.name] != "" && ns != a.parent.ingress.Namespace {
+			return fmt.Errorf("ConfigMap '%s/%s' is not in namespace '%s'", ns, name, a.parent.ingress.Namespace)
+		}
+		var cm *store.ConfigMap
+		if namespace, ok := k.Namespaces[ns]; ok {
+			cm = namespace.ConfigMaps[name]
+		}
+		if cm == nil {
+			return fmt.Errorf("ConfigMap '%s/%s' not found, is it labeled with 'haproxy.org/error-pages'?", ns, name)
+		}
+		content, ok := cm.Annotations[strconv.FormatInt(a.parent.rule.StatusCode, 10)]
+		if !ok {
+			return fmt.Errorf("ConfigMap '%s/%s' has no page for status code %d", ns, name, a.parent.rule.StatusCode)
+		}
+		a.parent.rule.ContentType = "text/html"
+		a.parent.rule.Content = content
+	case "maintenance-retry-after":
+		var seconds int64
+		seconds, err = strconv.ParseInt(input, 10, 64)
+		if err != nil || seconds < 0 {
+			return fmt.Errorf("invalid number of seconds '%s'", input)
+		}
+		a.parent.rule.Headers = append(a.parent.rule.Headers, rules.ReturnHeader{Name: "Retry-After", Fmt: input})
+	case "maintenance-allow-list":
+		addresses := strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == ' ' })
+		for _, address := range addresses {
+			if ip := net.ParseIP(address); ip == nil {
+				if _, _, err = net.ParseCIDR(address); err != nil {
+					return fmt.Errorf("incorrect address '%s'", address)
+				}
+			}
+		}
+		a.parent.rule.CondTest += fmt.Sprintf(" !{ src %s }", strings.Join(addresses, " "))
+	case "maintenance-bypass-header":
+		fields := strings.Fields(input)
+		if len(fields) != 2 || !headerNameRegex.MatchString(fields[0]) || strings.ContainsAny(fields[1], "\"'\\#{}") {
+			return fmt.Errorf("invalid value '%s', expected '<header name> <value>'", input)
+		}
+		a.parent.rule.CondTest += fmt.Sprintf(" !{ req.hdr(%s) -m str %s }", fields[0], fields[1])
+	default:
+		err = fmt.Errorf("unknown maintenance annotation '%s'", a.name)
+	}
+	return err
+}
@@ -24,9 +24,8 @@ func (a *ReqSetHost) Process(k store.K8s, annotations ...map[string]string) (err
 	if input == "" {
 		return err
 	}
-	a.rules.Add(&rules.SetHdr{
-		HdrName:   "Host",
-		HdrFormat: input,
+	a.rules.Add(&rules.ReqSetHost{
+		Host: input,
 	})
 	return err
 }
@@ -0,0 +1,90 @@
+package ingress
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations/common"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/rules"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+// ReqSetTimeout sets the timeout of the requests of the ingress paths.
+// The value is a timeout for all the paths, or lines of an ingress path followed by its timeout.
+type ReqSetTimeout struct {
+	rules       *rules.List
+	ingress     *store.Ingress
+	name        string
+	timeoutType string
+}
+
+func NewReqSetTimeout(n, timeoutType string, r *rules.List, i *store.Ingress) *ReqSetTimeout {
+	return &ReqSetTimeout{name: n, timeoutType: timeoutType, rules: r, ingress: i}
+}
+
+func (a *ReqSetTimeout) GetName() string {
+	return a.name
+}
+
+func (a *ReqSetTimeout) Process(k store.K8s, annotations ...map[string]string) (err error) {
+	input := strings.TrimSpace(common.GetValue(a.GetName(), annotations...))
+	if input == "" {
+		return err
+	}
+	if !strings.ContainsAny(input, " \t\n") {
+		timeout, errTime := utils.ParseTime(input)
+		if errTime != nil {
+			return errTime
+		}
+		a.rules.Add(&rules.ReqSetTimeout{
+			TimeoutType: a.timeoutType,
+			Timeout:     *timeout,
+		})
+		return err
+	}
+	if a.ingress == nil {
+		return fmt.Errorf("timeouts per path are only supported on ingresses, got '%s'", input)
+	}
+	pathTimeouts := map[int64][]string{}
+	timeouts := []int64{}
+	for _, line := range strings.Split(input, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return fmt.Errorf("invalid line '%s', expected '<path> <timeout>'", line)
+		}
+		if !ingressHasPath(a.ingress, fields[0]) {
+			return fmt.Errorf("no ingress path '%s'", fields[0])
+		}
+		timeout, errTime := utils.ParseTime(fields[1])
+		if errTime != nil {
+			return fmt.Errorf("path '%s': %w", fields[0], errTime)
+		}
+		if _, ok := pathTimeouts[*timeout]; !ok {
+			timeouts = append(timeouts, *timeout)
+		}
+		pathTimeouts[*timeout] = append(pathTimeouts[*timeout], fields[0])
+	}
+	for _, timeout := range timeouts {
+		a.rules.Add(&rules.ReqSetTimeout{
+			TimeoutType: a.timeoutType,
+			Timeout:     timeout,
+			Paths:       pathTimeouts[timeout],
+		})
+	}
+	return err
+}
+
+func ingressHasPath(ingress *store.Ingress, path string) bool {
+	for _, rule := range ingress.Rules {
+		for _, ingPath := range rule.Paths {
+			if ingPath.Path == path {
+				return true
+			}
+		}
+	}
+	return false
+}
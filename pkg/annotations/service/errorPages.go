@@ -0,0 +1,94 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/haproxytech/client-native/v6/models"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations/common"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/errorpages"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+// ErrorPages replies to the errors of the backend with the pages of a ConfigMap, one page per status code.
+// The ConfigMap is looked up in namespace when the annotation value has no namespace, and must be in
+// namespace unless anyNamespace is set, which is the case of values coming from the controller ConfigMap.
+type ErrorPages struct {
+	backend      *models.Backend
+	name         string
+	namespace    string
+	anyNamespace bool
+}
+
+func NewErrorPages(n, namespace string, anyNamespace bool, b *models.Backend) *ErrorPages {
+	return &ErrorPages{name: n, namespace: namespace, anyNamespace: anyNamespace, backend: b}
+}
+
+func (a *ErrorPages) GetName() string {
+	return a.name
+}
+
+func (a *ErrorPages) Process(k store.K8s, annotations ...map[string]string) error {
+	a.backend.HTTPErrorRuleList = nil
+	ns, name, err := common.GetK8sPath(a.name, annotations...)
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		return nil
+	}
+	if ns == "" {
+		ns = a.namespace
+	}
+	if ns == "" {
+		return errors.New("expected '<namespace>/<configmap>'")
+	}
+	if !a.anyNamespace && ns != a.namespace {
+		return fmt.Errorf("ConfigMap '%s/%s' is not in namespace '%s'", ns, name, a.namespace)
+	}
+	var cm *store.ConfigMap
+	if namespace, ok := k.Namespaces[ns]; ok {
+		cm = namespace.ConfigMaps[name]
+	}
+	if cm == nil {
+		return fmt.Errorf("ConfigMap '%s/%s' not found, is it labeled with 'haproxy.org/error-pages'?", ns, name)
+	}
+	codes := make([]string, 0, len(cm.Annotations))
+	for code := range cm.Annotations {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	unsupported := []string{}
+	var redirected string
+	for _, code := range codes {
+		if errorpages.CheckCode(code) != nil {
+			unsupported = append(unsupported, code)
+			continue
+		}
+		status, _ := strconv.ParseInt(code, 10, 64) // code already checked in CheckCode
+		if a.backend.Errorloc302 != nil && a.backend.Errorloc302.Code != nil && *a.backend.Errorloc302.Code == status {
+			// error-pages-redirect takes precedence over the page of the same status code.
+			redirected = code
+			continue
+		}
+		a.backend.HTTPErrorRuleList = append(a.backend.HTTPErrorRuleList, &models.HTTPErrorRule{
+			Type:                "status",
+			Status:              status,
+			ReturnContentType:   utils.PtrString("text/html"),
+			ReturnContentFormat: "file",
+			ReturnContent:       errorpages.GetPath(ns, name, code),
+		})
+	}
+	if redirected != "" {
+		return fmt.Errorf("ConfigMap '%s/%s': status code %s is redirected by 'error-pages-redirect', its page is ignored", ns, name, redirected)
+	}
+	if len(unsupported) != 0 {
+		return fmt.Errorf("ConfigMap '%s/%s': unsupported status codes %s", ns, name, strings.Join(unsupported, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/haproxytech/client-native/v6/models"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations/common"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/errorpages"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+// ErrorPagesRedirect replies to the errors of the backend having a status code with a 302 redirection,
+// using the errorloc302 directive.
+type ErrorPagesRedirect struct {
+	backend *models.Backend
+	name    string
+}
+
+func NewErrorPagesRedirect(n string, b *models.Backend) *ErrorPagesRedirect {
+	return &ErrorPagesRedirect{name: n, backend: b}
+}
+
+func (a *ErrorPagesRedirect) GetName() string {
+	return a.name
+}
+
+func (a *ErrorPagesRedirect) Process(k store.K8s, annotations ...map[string]string) error {
+	input := common.GetValue(a.GetName(), annotations...)
+	if input == "" {
+		a.backend.Errorloc302 = nil
+		return nil
+	}
+	fields := strings.Fields(input)
+	if len(fields) != 2 {
+		a.backend.Errorloc302 = nil
+		return fmt.Errorf("invalid value '%s', expected '<code> <url>'", input)
+	}
+	if err := errorpages.CheckCode(fields[0]); err != nil {
+		a.backend.Errorloc302 = nil
+		return err
+	}
+	code, _ := strconv.ParseInt(fields[0], 10, 64) // code already checked in CheckCode
+	a.backend.Errorloc302 = &models.Errorloc{
+		Code: utils.PtrInt64(code),
+		URL:  utils.PtrString(fields[1]),
+	}
+	return nil
+}
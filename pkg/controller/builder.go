@@ -184,7 +184,7 @@ func (builder *Builder) Build() *HAProxyController {
 	builder.store.GatewayControllerName = builder.osArgs.GatewayControllerName
 	gatewayManager := builder.gatewayManager
 	if gatewayManager == nil {
-		gatewayManager = gateway.New(builder.store, haproxy.HAProxyClient, builder.osArgs, builder.restClientSet)
+		gatewayManager = gateway.New(builder.store, haproxy, builder.annotations, builder.osArgs, builder.restClientSet)
 	}
 	updateStatusManager := builder.updateStatusManager
 	if updateStatusManager == nil {
@@ -207,6 +207,7 @@ func (builder *Builder) Build() *HAProxyController {
 		updatePublishServiceFunc: builder.updatePublishServiceFunc,
 		gatewayManager:           gatewayManager,
 		updateStatusManager:      updateStatusManager,
+		eventRecorder:            status.NewEventRecorder(builder.clientSet),
 		prometheusMetricsManager: metrics.New(),
 		PodIP:                    podIP,
 		Hostname:                 hostname,
@@ -0,0 +1,409 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/ingress"
+	"github.com/haproxytech/kubernetes-ingress/pkg/route"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+)
+
+// Policies deciding which ingress gets a host/path claimed by several ingresses.
+//
+//nolint:golint,stylecheck
+const (
+	INGRESS_CONFLICT_POLICY_NONE   = "none"
+	INGRESS_CONFLICT_POLICY_OLDEST = "oldest"
+)
+
+// Reasons of the events reported for the ingress paths rejected by the host/path ownership policies and the canary checks.
+const (
+	reasonHostPathConflict        = "HostPathConflict"
+	reasonHostNotAllowed          = "HostNotAllowed"
+	reasonInvalidCanary           = "InvalidCanary"
+	reasonCanaryWithoutProduction = "CanaryWithoutProduction"
+	reasonHostDefaultConflict     = "HostDefaultBackendConflict"
+)
+
+// pathRejection is the reason why an ingress path is not configured, or conflicts with the path of another ingress.
+type pathRejection struct {
+	reason  string
+	message string
+}
+
+// hostOwner restricts the namespaces allowed to claim the hosts matching the pattern,
+// the selector matches the namespace labels, or its annotations when annotations is set.
+type hostOwner struct {
+	selector    labels.Selector
+	pattern     string
+	annotations bool
+}
+
+// ingressClaim is an ingress path claiming a host.
+type ingressClaim struct {
+	ingress            *store.Ingress
+	path               *store.IngressPath
+	canaryErr          error
+	host               string
+	canary             bool
+	hostDefaultBackend bool
+}
+
+// hostDefaultRejection is a host-default-backend annotation ignored for a host with an older default backend.
+type hostDefaultRejection struct {
+	ingress *store.Ingress
+	host    string
+	message string
+}
+
+// checkIngressOwnership applies the host/path ownership policies of the controller ConfigMap and the canary checks to the ingresses,
+// the rejected paths are not configured and are reported with a Kubernetes event and a metric.
+// Paths of a host/path claimed by several ingresses are reported the same way whatever the policy, which only decides whether they are configured.
+// The default backend of each host is then the one of the oldest ingress setting it, the other ones are reported with an event.
+func (c *HAProxyController) checkIngressOwnership() {
+	policy := annotations.String("ingress-conflict-policy", c.store.ConfigMaps.Main.Annotations)
+	switch policy {
+	case "":
+		policy = INGRESS_CONFLICT_POLICY_NONE
+	case INGRESS_CONFLICT_POLICY_NONE, INGRESS_CONFLICT_POLICY_OLDEST:
+	default:
+		logger.Errorf("ingress-conflict-policy: unknown policy '%s', ignored", policy)
+		policy = INGRESS_CONFLICT_POLICY_NONE
+	}
+	owners := parseHostOwners(annotations.String("host-ownership", c.store.ConfigMaps.Main.Annotations))
+
+	claims := c.ingressClaims()
+	nsMeta := func(namespace string) (map[string]string, map[string]string) {
+		if ns, ok := c.store.Namespaces[namespace]; ok {
+			return ns.Labels, ns.Annotations
+		}
+		return nil, nil
+	}
+	rejected, conflicts := rejectIngressPaths(claims, policy, owners, nsMeta)
+	c.rejectedPaths = rejected
+	rejectCanaryPaths(claims, c.rejectedPaths)
+	hostDefaultOwners, hostDefaultRejections := hostDefaultBackendOwners(claims, c.rejectedPaths)
+	route.SetHostDefaultOwners(hostDefaultOwners)
+	if len(c.rejectedPaths) == 0 && len(conflicts) == 0 && len(hostDefaultRejections) == 0 && len(c.reportedRejections) == 0 {
+		return
+	}
+
+	// Events are only reported for new rejections and conflicts, the metric reflects the current ones.
+	reported := make(map[string]struct{}, len(c.rejectedPaths)+len(conflicts)+len(hostDefaultRejections))
+	report := func(ing *store.Ingress, key, reason, message string) {
+		reported[key] = struct{}{}
+		if _, ok := c.reportedRejections[key]; ok || c.eventRecorder == nil {
+			return
+		}
+		c.eventRecorder.IngressWarning(ing, reason, message)
+	}
+	counts := map[[3]string]int{}
+	for _, claim := range claims {
+		rejection, ok := c.rejectedPaths[claim.path]
+		if !ok {
+			if rejection, ok = conflicts[claim.path]; !ok {
+				continue
+			}
+		}
+		ing := claim.ingress
+		counts[[3]string{ing.Namespace, ing.Name, rejection.reason}]++
+		key := strings.Join([]string{ing.Namespace, ing.Name, claim.host, claim.path.PathTypeMatch, claim.path.Path, rejection.reason}, "|")
+		report(ing, key, rejection.reason, rejection.message)
+	}
+	for _, rejection := range hostDefaultRejections {
+		ing := rejection.ingress
+		key := strings.Join([]string{ing.Namespace, ing.Name, rejection.host, reasonHostDefaultConflict}, "|")
+		report(ing, key, reasonHostDefaultConflict, rejection.message)
+	}
+	c.reportedRejections = reported
+	c.prometheusMetricsManager.SetRejectedIngressPaths(counts)
+}
+
+// ingressClaims returns the paths of the ingress rules handled by the controller.
+// The ingress class is checked without updating the ingress status, done later when the ingress is processed.
+func (c *HAProxyController) ingressClaims() []ingressClaim {
+	claims := []ingressClaim{}
+	for _, namespace := range c.store.Namespaces {
+		for _, ing := range namespace.Ingresses {
+			if !namespace.Relevant && !ing.Faked || ing.Status == store.DELETED {
+				continue
+			}
+			if !ing.Faked && !c.store.IsIngressClassSupported(ing.Class, c.osArgs.IngressClass, c.osArgs.EmptyIngressClass) {
+				continue
+			}
+			canary, _ := annotations.Bool("canary", ing.Annotations)
+			canaryErr := ingress.CanaryError(ing, c.store.ConfigMaps.Main.Annotations)
+			hostDefaultBackend := annotations.String("host-default-backend", ing.Annotations) != ""
+			for _, rule := range ing.Rules {
+				for _, path := range rule.Paths {
+					claims = append(claims, ingressClaim{
+						ingress:            ing,
+						path:               path,
+						host:               rule.Host,
+						canary:             canary,
+						canaryErr:          canaryErr,
+						hostDefaultBackend: hostDefaultBackend,
+					})
+				}
+			}
+		}
+	}
+	return claims
+}
+
+// withoutRejectedPaths returns the ingress, or a copy of it without the paths rejected by the ownership policies.
+func (c *HAProxyController) withoutRejectedPaths(ing *store.Ingress) *store.Ingress {
+	if !c.hasRejectedPaths(ing) {
+		return ing
+	}
+	ingCopy := *ing
+	ingCopy.Rules = map[string]*store.IngressRule{}
+	for host, rule := range ing.Rules {
+		ruleCopy := &store.IngressRule{
+			Host:  rule.Host,
+			Paths: map[string]*store.IngressPath{},
+		}
+		for key, path := range rule.Paths {
+			if _, rejected := c.rejectedPaths[path]; !rejected {
+				ruleCopy.Paths[key] = path
+			}
+		}
+		if len(ruleCopy.Paths) > 0 {
+			ingCopy.Rules[host] = ruleCopy
+		}
+	}
+	return &ingCopy
+}
+
+func (c *HAProxyController) hasRejectedPaths(ing *store.Ingress) bool {
+	for _, rule := range ing.Rules {
+		for _, path := range rule.Paths {
+			if _, rejected := c.rejectedPaths[path]; rejected {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// rejectIngressPaths returns the claims rejected by the host owners, then the claims of a host/path already claimed by an older ingress:
+// they are rejected with the oldest policy, otherwise they are returned as conflicts, configured but reported.
+// The owner of a host/path is its oldest non canary ingress, canary ingresses of its namespace share it,
+// the canary ingresses of other namespaces conflict like any other claim.
+func rejectIngressPaths(claims []ingressClaim, policy string, owners []hostOwner, nsMeta func(namespace string) (nsLabels, nsAnnotations map[string]string)) (rejected, conflicts map[*store.IngressPath]pathRejection) {
+	rejected = map[*store.IngressPath]pathRejection{}
+	conflicts = map[*store.IngressPath]pathRejection{}
+	claimsByHostPath := map[string][]ingressClaim{}
+	for _, claim := range claims {
+		nsLabels, nsAnnotations := nsMeta(claim.ingress.Namespace)
+		if allowed, selectors := hostAllowed(owners, claim.host, nsLabels, nsAnnotations); !allowed {
+			rejected[claim.path] = pathRejection{
+				reason:  reasonHostNotAllowed,
+				message: fmt.Sprintf("host '%s' can only be claimed by namespaces matching %s, path '%s' ignored", claim.host, selectors, claim.path.Path),
+			}
+			continue
+		}
+		key := claim.hostPathKey()
+		claimsByHostPath[key] = append(claimsByHostPath[key], claim)
+	}
+	for _, hostPathClaims := range claimsByHostPath {
+		sort.Slice(hostPathClaims, func(i, j int) bool {
+			return olderIngress(hostPathClaims[i].ingress, hostPathClaims[j].ingress)
+		})
+		owner := hostPathClaims[0].ingress
+		for _, claim := range hostPathClaims {
+			if !claim.canary {
+				owner = claim.ingress
+				break
+			}
+		}
+		for _, claim := range hostPathClaims {
+			if claim.ingress == owner || claim.canary && claim.ingress.Namespace == owner.Namespace {
+				continue
+			}
+			if policy == INGRESS_CONFLICT_POLICY_OLDEST {
+				rejected[claim.path] = pathRejection{
+					reason: reasonHostPathConflict,
+					message: fmt.Sprintf("host '%s' and %s path '%s' already claimed by the older Ingress '%s/%s', path ignored",
+						claim.host, claim.path.PathTypeMatch, claim.path.Path, owner.Namespace, owner.Name),
+				}
+				continue
+			}
+			conflicts[claim.path] = pathRejection{
+				reason: reasonHostPathConflict,
+				message: fmt.Sprintf("host '%s' and %s path '%s' already claimed by the older Ingress '%s/%s', both are configured with ingress-conflict-policy '%s'",
+					claim.host, claim.path.PathTypeMatch, claim.path.Path, owner.Namespace, owner.Name, policy),
+			}
+		}
+	}
+	return rejected, conflicts
+}
+
+// rejectCanaryPaths adds to the rejected claims the paths of the canary ingresses with invalid canary annotations,
+// and the paths of the canary ingresses without a non rejected production ingress path of the same host and path.
+func rejectCanaryPaths(claims []ingressClaim, rejected map[*store.IngressPath]pathRejection) {
+	production := map[string]struct{}{}
+	for _, claim := range claims {
+		if _, ok := rejected[claim.path]; !ok && !claim.canary && claim.canaryErr == nil {
+			production[claim.hostPathKey()] = struct{}{}
+		}
+	}
+	for _, claim := range claims {
+		if _, ok := rejected[claim.path]; ok {
+			continue
+		}
+		switch {
+		case claim.canaryErr != nil:
+			rejected[claim.path] = pathRejection{
+				reason:  reasonInvalidCanary,
+				message: fmt.Sprintf("canary: %s, path '%s' ignored", claim.canaryErr, claim.path.Path),
+			}
+		case claim.canary:
+			if _, ok := production[claim.hostPathKey()]; ok {
+				continue
+			}
+			rejected[claim.path] = pathRejection{
+				reason: reasonCanaryWithoutProduction,
+				message: fmt.Sprintf("no production Ingress for host '%s' and %s path '%s', canary path ignored",
+					claim.host, claim.path.PathTypeMatch, claim.path.Path),
+			}
+		}
+	}
+}
+
+// hostDefaultBackendOwners returns the owner of the default backend of each host, the oldest ingress setting
+// the host-default-backend annotation with a non rejected path of the host, and the rejections of the other ingresses setting it.
+func hostDefaultBackendOwners(claims []ingressClaim, rejected map[*store.IngressPath]pathRejection) (map[string]string, []hostDefaultRejection) {
+	owners := map[string]*store.Ingress{}
+	hostClaims := []ingressClaim{}
+	for _, claim := range claims {
+		if _, ok := rejected[claim.path]; ok || !claim.hostDefaultBackend || claim.host == "" {
+			continue
+		}
+		hostClaims = append(hostClaims, claim)
+		if owner, ok := owners[claim.host]; !ok || olderIngress(claim.ingress, owner) {
+			owners[claim.host] = claim.ingress
+		}
+	}
+	rejections := []hostDefaultRejection{}
+	seen := map[string]struct{}{}
+	for _, claim := range hostClaims {
+		owner := owners[claim.host]
+		key := claim.ingress.Namespace + "/" + claim.ingress.Name + "|" + claim.host
+		if _, ok := seen[key]; ok || claim.ingress == owner {
+			continue
+		}
+		seen[key] = struct{}{}
+		rejections = append(rejections, hostDefaultRejection{
+			ingress: claim.ingress,
+			host:    claim.host,
+			message: fmt.Sprintf("default backend of host '%s' already set by the older Ingress '%s/%s', host-default-backend ignored for this host",
+				claim.host, owner.Namespace, owner.Name),
+		})
+	}
+	ownerNames := make(map[string]string, len(owners))
+	for host, owner := range owners {
+		ownerNames[host] = owner.Namespace + "/" + owner.Name
+	}
+	return ownerNames, rejections
+}
+
+// hostPathKey returns the key of the host and path claimed.
+func (claim ingressClaim) hostPathKey() string {
+	return claim.host + "|" + claim.path.PathTypeMatch + "|" + claim.path.Path
+}
+
+// olderIngress orders ingresses by creation time, then by namespace and name.
+func olderIngress(a, b *store.Ingress) bool {
+	if !a.CreationTime.Equal(b.CreationTime) {
+		return a.CreationTime.Before(b.CreationTime)
+	}
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
+
+// hostAllowed returns whether the namespace labels or annotations match one of the owners of the host, when it has some,
+// otherwise the selectors of its owners.
+func hostAllowed(owners []hostOwner, host string, nsLabels, nsAnnotations labels.Set) (bool, string) {
+	selectors := []string{}
+	for _, owner := range owners {
+		if !hostMatch(owner.pattern, host) {
+			continue
+		}
+		if owner.annotations && owner.selector.Matches(nsAnnotations) || !owner.annotations && owner.selector.Matches(nsLabels) {
+			return true, ""
+		}
+		if owner.annotations {
+			selectors = append(selectors, "'"+hostOwnerAnnotationsPrefix+owner.selector.String()+"'")
+		} else {
+			selectors = append(selectors, "'"+owner.selector.String()+"'")
+		}
+	}
+	return len(selectors) == 0, strings.Join(selectors, " or ")
+}
+
+// hostMatch returns whether the host, possibly a wildcard one, matches the pattern.
+// The pattern is a host, '*.' followed by a domain matching its subdomains, or '*' matching any host.
+func hostMatch(pattern, host string) bool {
+	switch {
+	case pattern == "*":
+		return true
+	case strings.HasPrefix(pattern, "*."):
+		return strings.HasSuffix(host, pattern[1:])
+	default:
+		return host == pattern
+	}
+}
+
+// hostOwnerAnnotationsPrefix prefixes the host-ownership selectors matching the namespace annotations instead of its labels.
+const hostOwnerAnnotationsPrefix = "annotations:"
+
+// parseHostOwners parses the lines of the host-ownership value, made of a host pattern followed by a namespace label selector,
+// or a namespace annotation selector prefixed with 'annotations:'.
+// A host with an invalid selector is reserved to no namespace.
+func parseHostOwners(value string) []hostOwner {
+	owners := []hostOwner{}
+	for _, line := range strings.Split(value, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		pattern, selector, _ := strings.Cut(line, " ")
+		selector = strings.TrimSpace(selector)
+		owner := hostOwner{pattern: pattern, selector: labels.Nothing()}
+		if after, found := strings.CutPrefix(selector, hostOwnerAnnotationsPrefix); found {
+			owner.annotations = true
+			selector = strings.TrimSpace(after)
+		}
+		if selector == "" {
+			logger.Errorf("host-ownership: missing namespace selector for host '%s', host reserved to no namespace", pattern)
+		} else if parsed, err := labels.Parse(selector); err != nil {
+			logger.Errorf("host-ownership: invalid namespace selector for host '%s', host reserved to no namespace: %s", pattern, err)
+		} else {
+			owner.selector = parsed
+		}
+		owners = append(owners, owner)
+	}
+	return owners
+}
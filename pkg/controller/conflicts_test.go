@@ -0,0 +1,205 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+)
+
+// newClaim builds a claim of the host and prefix path by an ingress created at the given time.
+func newClaim(namespace, name string, created time.Time, host, path string) ingressClaim {
+	ing := newIngress(namespace, name, false, nil)
+	ing.CreationTime = created
+	return ingressClaim{
+		ingress: ing,
+		path:    &store.IngressPath{Path: path, PathTypeMatch: store.PATH_TYPE_PREFIX},
+		host:    host,
+	}
+}
+
+func TestRejectIngressPathsOldestWins(t *testing.T) {
+	now := time.Now()
+	older := newClaim("team-a", "shop", now.Add(-time.Hour), "shop.example.com", "/")
+	newer := newClaim("team-b", "hijack", now, "shop.example.com", "/")
+	otherPath := newClaim("team-b", "hijack", now, "shop.example.com", "/other")
+	noMeta := func(string) (map[string]string, map[string]string) { return nil, nil }
+
+	// Claims are given newest first to check that the processing order doesn't matter.
+	claims := []ingressClaim{otherPath, newer, older}
+
+	rejected, conflicts := rejectIngressPaths(claims, INGRESS_CONFLICT_POLICY_NONE, nil, noMeta)
+	if len(rejected) != 0 {
+		t.Fatalf("no path should be rejected without policy, got %d", len(rejected))
+	}
+	if conflict, ok := conflicts[newer.path]; !ok || len(conflicts) != 1 || conflict.reason != reasonHostPathConflict {
+		t.Fatalf("expected only the newer claim to be reported as a conflict without policy, got %+v", conflicts)
+	}
+
+	rejected, conflicts = rejectIngressPaths(claims, INGRESS_CONFLICT_POLICY_OLDEST, nil, noMeta)
+	if len(rejected) != 1 || len(conflicts) != 0 {
+		t.Fatalf("expected only the newer claim to be rejected, got %d rejections and %d conflicts", len(rejected), len(conflicts))
+	}
+	rejection, ok := rejected[newer.path]
+	if !ok || rejection.reason != reasonHostPathConflict {
+		t.Fatalf("expected the newer claim to be rejected with %s, got %+v", reasonHostPathConflict, rejection)
+	}
+}
+
+func TestRejectIngressPathsCanary(t *testing.T) {
+	now := time.Now()
+	production := newClaim("team-a", "shop", now, "shop.example.com", "/")
+	sameNamespace := newClaim("team-a", "shop-canary", now.Add(-time.Hour), "shop.example.com", "/")
+	sameNamespace.canary = true
+	otherNamespace := newClaim("team-b", "hijack", now.Add(-time.Hour), "shop.example.com", "/")
+	otherNamespace.canary = true
+	noMeta := func(string) (map[string]string, map[string]string) { return nil, nil }
+
+	rejected, _ := rejectIngressPaths([]ingressClaim{otherNamespace, sameNamespace, production}, INGRESS_CONFLICT_POLICY_OLDEST, nil, noMeta)
+	if len(rejected) != 1 {
+		t.Fatalf("expected only the canary of the other namespace to be rejected, got %d rejections", len(rejected))
+	}
+	rejection, ok := rejected[otherNamespace.path]
+	if !ok || rejection.reason != reasonHostPathConflict {
+		t.Fatalf("expected the canary of the other namespace to be rejected with %s, got %+v", reasonHostPathConflict, rejection)
+	}
+}
+
+func TestRejectIngressPathsSameCreationTime(t *testing.T) {
+	now := time.Now()
+	a := newClaim("ns-a", "ing", now, "example.com", "/")
+	b := newClaim("ns-b", "ing", now, "example.com", "/")
+	noMeta := func(string) (map[string]string, map[string]string) { return nil, nil }
+
+	for _, claims := range [][]ingressClaim{{a, b}, {b, a}} {
+		rejected, _ := rejectIngressPaths(claims, INGRESS_CONFLICT_POLICY_OLDEST, nil, noMeta)
+		if _, ok := rejected[b.path]; !ok || len(rejected) != 1 {
+			t.Fatalf("expected the claim of namespace 'ns-b' to be rejected, got %+v", rejected)
+		}
+	}
+}
+
+func TestRejectIngressPathsHostOwnership(t *testing.T) {
+	owners := parseHostOwners(`
+# shop hosts belong to team a
+*.shop.example.com team=a
+api.example.com    team in (a, b)
+admin.example.com  annotations: example.com/owner=a
+`)
+	if len(owners) != 3 {
+		t.Fatalf("expected 3 host owners, got %d", len(owners))
+	}
+	nsMeta := func(namespace string) (map[string]string, map[string]string) {
+		return map[string]string{"team": namespace}, map[string]string{"example.com/owner": namespace}
+	}
+	now := time.Now()
+	tests := []struct {
+		claim    ingressClaim
+		rejected bool
+	}{
+		{newClaim("a", "ing", now, "www.shop.example.com", "/"), false},
+		{newClaim("b", "ing", now, "www.shop.example.com", "/"), true},
+		{newClaim("b", "ing", now, "*.shop.example.com", "/"), true},
+		{newClaim("b", "ing", now, "api.example.com", "/"), false},
+		{newClaim("c", "ing", now, "api.example.com", "/"), true},
+		{newClaim("c", "ing", now, "www.example.com", "/"), false},
+		{newClaim("a", "ing", now, "admin.example.com", "/"), false},
+		{newClaim("b", "ing", now, "admin.example.com", "/"), true},
+	}
+	for _, test := range tests {
+		rejected, _ := rejectIngressPaths([]ingressClaim{test.claim}, INGRESS_CONFLICT_POLICY_NONE, owners, nsMeta)
+		rejection, ok := rejected[test.claim.path]
+		if ok != test.rejected {
+			t.Errorf("host '%s' claimed by namespace '%s': expected rejected=%t, got %t", test.claim.host, test.claim.ingress.Namespace, test.rejected, ok)
+		}
+		if ok && rejection.reason != reasonHostNotAllowed {
+			t.Errorf("host '%s': expected reason %s, got %s", test.claim.host, reasonHostNotAllowed, rejection.reason)
+		}
+	}
+}
+
+func TestParseHostOwnersInvalidSelector(t *testing.T) {
+	owners := parseHostOwners("example.com\nexample.org team=(\nexample.net annotations:")
+	if len(owners) != 3 {
+		t.Fatalf("expected 3 host owners, got %d", len(owners))
+	}
+	for _, owner := range owners {
+		if owner.selector.Matches(labels.Set{"team": "a"}) {
+			t.Errorf("host '%s' should be reserved to no namespace", owner.pattern)
+		}
+	}
+}
+
+func TestRejectCanaryPaths(t *testing.T) {
+	now := time.Now()
+	production := newClaim("ns", "shop", now, "shop.example.com", "/")
+	canary := newClaim("ns", "shop-canary", now, "shop.example.com", "/")
+	canary.canary = true
+	orphan := newClaim("ns", "shop-canary", now, "shop.example.com", "/beta")
+	orphan.canary = true
+	invalid := newClaim("ns", "invalid-canary", now, "shop.example.com", "/")
+	invalid.canary = true
+	invalid.canaryErr = errors.New("canary ingress requires canary-by-header, canary-by-cookie or canary-weight")
+
+	rejected := map[*store.IngressPath]pathRejection{}
+	rejectCanaryPaths([]ingressClaim{orphan, invalid, canary, production}, rejected)
+	tests := []struct {
+		claim  ingressClaim
+		reason string
+	}{
+		{production, ""},
+		{canary, ""},
+		{orphan, reasonCanaryWithoutProduction},
+		{invalid, reasonInvalidCanary},
+	}
+	for _, test := range tests {
+		if rejected[test.claim.path].reason != test.reason {
+			t.Errorf("Ingress '%s' path '%s': expected reason '%s', got %+v", test.claim.ingress.Name, test.claim.path.Path, test.reason, rejected[test.claim.path])
+		}
+	}
+
+	// A production path rejected by the ownership policies cannot be shared.
+	rejected = map[*store.IngressPath]pathRejection{production.path: {reason: reasonHostPathConflict}}
+	rejectCanaryPaths([]ingressClaim{canary, production}, rejected)
+	if rejected[canary.path].reason != reasonCanaryWithoutProduction {
+		t.Errorf("expected the canary of a rejected production path to be rejected, got %+v", rejected[canary.path])
+	}
+}
+
+func TestHostDefaultBackendOwners(t *testing.T) {
+	now := time.Now()
+	older := newClaim("team-b", "shop", now.Add(-time.Hour), "shop.example.com", "/")
+	older.hostDefaultBackend = true
+	newer := newClaim("team-a", "shop", now, "shop.example.com", "/")
+	newer.hostDefaultBackend = true
+	newerOtherHost := newClaim("team-a", "shop", now, "www.example.com", "/")
+	newerOtherHost.hostDefaultBackend = true
+	rejectedPath := newClaim("team-c", "shop", now.Add(-2*time.Hour), "shop.example.com", "/")
+	rejectedPath.hostDefaultBackend = true
+	rejected := map[*store.IngressPath]pathRejection{rejectedPath.path: {reason: reasonHostNotAllowed}}
+
+	owners, rejections := hostDefaultBackendOwners([]ingressClaim{newerOtherHost, newer, older, rejectedPath}, rejected)
+	if owners["shop.example.com"] != "team-b/shop" || owners["www.example.com"] != "team-a/shop" {
+		t.Errorf("expected the oldest ingress with a non rejected path to own the default backend of each host, got %v", owners)
+	}
+	if len(rejections) != 1 || rejections[0].ingress != newer.ingress || rejections[0].host != "shop.example.com" {
+		t.Errorf("expected only the default backend of the newer ingress for 'shop.example.com' to be rejected, got %+v", rejections)
+	}
+}
@@ -64,6 +64,9 @@ type HAProxyController struct {
 	auxCfgModTime            int64
 	ready                    bool
 	processIngress           func()
+	eventRecorder            status.EventRecorder
+	rejectedPaths            map[*store.IngressPath]pathRejection
+	reportedRejections       map[string]struct{}
 }
 
 // Wrapping a Native-Client transaction and commit it.
@@ -156,7 +159,14 @@ func (c *HAProxyController) updateHAProxy() {
 	}
 
 	c.processSSLPassthroughInConfigFile()
+	c.checkIngressOwnership()
 	c.processIngress()
+	logger.Error(route.CustomRoutesApply(c.haproxy))
+	if rule := route.CustomRoutesRule(); rule != nil {
+		for _, frontend := range []string{c.haproxy.FrontHTTP, c.haproxy.FrontHTTPS} {
+			logger.Error(c.haproxy.AddRule(frontend, rule, false))
+		}
+	}
 
 	updated := deep.Equal(route.CurentCustomRoutes, route.CustomRoutes, deep.FLAG_IGNORE_SLICE_ORDER)
 	if len(updated) != 0 {
@@ -327,6 +337,12 @@ func (c *HAProxyController) setupHAProxyRules() error {
 				Scope:      "txn",
 				Expression: fmt.Sprintf("var(txn.host_match),concat(,txn.path,),map(%s)", maps.GetPath(route.PATH_EXACT)),
 			}, false),
+			c.haproxy.AddRule(frontend, rules.ReqSetVar{
+				Name:       "path_match",
+				Scope:      "txn",
+				Expression: fmt.Sprintf("var(txn.host_match),concat(,txn.path,),map_reg(%s)", maps.GetPath(route.PATH_REGEX)),
+				CondTest:   "!{ var(txn.path_match) -m found }",
+			}, false),
 			c.haproxy.AddRule(frontend, rules.ReqSetVar{
 				Name:       "path_match",
 				Scope:      "txn",
@@ -440,7 +456,10 @@ func (c *HAProxyController) processIngressesWithMerge() {
 						Paths: map[string]*store.IngressPath{},
 					}
 					for _, path := range rule.Paths {
-						// if the rule refers to the service then keep it ...
+						// if the rule refers to the service and is not rejected by the ownership policies then keep it ...
+						if _, rejected := c.rejectedPaths[path]; rejected {
+							continue
+						}
 						if path.SvcNamespace == service.Namespace && path.SvcName == service.Name {
 							newRule.Paths[path.Path] = path
 						}
@@ -456,7 +475,7 @@ func (c *HAProxyController) processIngressesWithMerge() {
 			}
 			// Now process the standalone ingresses as usual.
 			for _, standaloneIngress := range standaloneIngresses {
-				c.manageIngress(standaloneIngress)
+				c.manageIngress(c.withoutRejectedPaths(standaloneIngress))
 			}
 		}
 	}
@@ -472,7 +491,7 @@ func (c *HAProxyController) processIngressesDefaultImplementation() {
 				// There should only be fake ingresses in irrelevant namespaces so loop should be whithin small amount of ingresses (Prometheus)
 				continue
 			}
-			c.manageIngress(ingResource)
+			c.manageIngress(c.withoutRejectedPaths(ingResource))
 		}
 	}
 }
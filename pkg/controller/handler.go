@@ -42,6 +42,7 @@ func (c *HAProxyController) initHandlers() {
 		},
 		handler.ProxyProtocol{},
 		&handler.ErrorFiles{},
+		&handler.ErrorPages{},
 		handler.TCPServices{
 			CertDir:  c.haproxy.Certs.FrontendDir,
 			IPv4:     !c.osArgs.DisableIPV4,
@@ -70,6 +70,20 @@ func (c *HAProxyController) SyncData() {
 				data = job.Data.(*v3.Frontend)
 			}
 			change = c.store.EventFrontendCR(job.Namespace, job.Name, data)
+		case k8ssync.CR_STATIC_RESP:
+			var data *v3.StaticResponse
+			if job.Data != nil {
+				//revive:disable-next-line:unchecked-type-assertion
+				data = job.Data.(*v3.StaticResponse)
+			}
+			change = c.store.EventStaticResponseCR(job.Namespace, job.Name, data)
+		case k8ssync.CR_SPLIT:
+			var data *v3.TrafficSplit
+			if job.Data != nil {
+				//revive:disable-next-line:unchecked-type-assertion
+				data = job.Data.(*v3.TrafficSplit)
+			}
+			change = c.store.EventTrafficSplitCR(job.Namespace, job.Name, data)
 		case k8ssync.NAMESPACE:
 			//revive:disable-next-line:unchecked-type-assertion
 			change = c.store.EventNamespace(ns, job.Data.(*store.Namespace))
@@ -103,6 +117,10 @@ func (c *HAProxyController) SyncData() {
 			change = c.store.EventGateway(ns, job.Data.(*store.Gateway))
 		case k8ssync.TCPROUTE:
 			change = c.store.EventTCPRoute(ns, job.Data.(*store.TCPRoute))
+		case k8ssync.HTTPROUTE:
+			change = c.store.EventHTTPRoute(ns, job.Data.(*store.HTTPRoute))
+		case k8ssync.TLSROUTE:
+			change = c.store.EventTLSRoute(ns, job.Data.(*store.TLSRoute))
 		case k8ssync.REFERENCEGRANT:
 			change = c.store.EventReferenceGrant(ns, job.Data.(*store.ReferenceGrant))
 		case k8ssync.CUSTOM_RESOURCE:
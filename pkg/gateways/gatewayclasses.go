@@ -0,0 +1,297 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gateway
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/haproxytech/client-native/v6/models"
+	v3 "github.com/haproxytech/kubernetes-ingress/crs/api/ingress/v3"
+	"github.com/haproxytech/kubernetes-ingress/pkg/controller/constants"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/instance"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+)
+
+//nolint:golint,stylecheck
+const (
+	K8S_DEFAULTS_CR_KIND = "Defaults"
+	K8S_BACKEND_CR_KIND  = "Backend"
+	K8S_GLOBAL_CR_KIND   = "Global"
+)
+
+// gatewayClassParameters holds the custom resource referenced by the parametersRef of a gatewayclass.
+// A Defaults CR configures the frontends of the gateways and the backends of their routes,
+// a Backend CR is the base of the backends of the routes.
+type gatewayClassParameters struct {
+	defaults *models.Defaults
+	backend  *v3.BackendSpec
+}
+
+// manageGatewayClass updates status for matching gatewayclasses and resolves their parametersRef.
+// A reload is triggered when the parameters applied to a gatewayclass have changed.
+func (gm GatewayManagerImpl) manageGatewayClass() {
+	for name, gatewayclass := range gm.k8sStore.GatewayClasses {
+		if gatewayclass.ControllerName != gm.k8sStore.GatewayControllerName || gatewayclass.Status == store.DELETED {
+			delete(gm.paramsByClass, name)
+			instance.ReloadIf(gm.paramsFingerprint[name] != "", "parameters of gatewayclass '%s' removed", name)
+			delete(gm.paramsFingerprint, name)
+			delete(gm.invalidParams, name)
+			continue
+		}
+		params, err := gm.getGatewayClassParameters(*gatewayclass)
+		invalidParameters := ""
+		if err != nil {
+			invalidParameters = err.Error()
+			logger.Errorf("gwapi: gatewayclass '%s': %s", name, err)
+		}
+		previousInvalidParameters, known := gm.invalidParams[name]
+		gm.invalidParams[name] = invalidParameters
+		if gatewayclass.Status == store.ADDED || gatewayclass.Status == store.MODIFIED ||
+			!known || previousInvalidParameters != invalidParameters {
+			if invalidParameters != "" {
+				gm.statusManager.SetGatewayClassReasonInvalidParameters(*gatewayclass, invalidParameters)
+			} else {
+				gm.statusManager.SetGatewayClassConditionStatusAccepted(*gatewayclass)
+			}
+		}
+
+		gm.paramsByClass[name] = params
+		fingerprint := params.fingerprint()
+		instance.ReloadIf(gm.paramsFingerprint[name] != fingerprint, "parameters of gatewayclass '%s' modified", name)
+		if fingerprint == "" {
+			delete(gm.paramsFingerprint, name)
+			continue
+		}
+		gm.paramsFingerprint[name] = fingerprint
+	}
+}
+
+// getGatewayClassParameters resolves the custom resource referenced by the parametersRef of the gatewayclass.
+func (gm GatewayManagerImpl) getGatewayClassParameters(gatewayclass store.GatewayClass) (params gatewayClassParameters, err error) {
+	ref := gatewayclass.ParametersRef
+	if ref == nil {
+		return
+	}
+	if ref.Group != v3.GroupName {
+		return params, fmt.Errorf("parametersRef group '%s' is not supported, only '%s' is", ref.Group, v3.GroupName)
+	}
+	if ref.Namespace == nil || *ref.Namespace == "" {
+		return params, errors.New("parametersRef must have a namespace")
+	}
+	ns, ok := gm.k8sStore.Namespaces[*ref.Namespace]
+	if !ok || ns.CRs == nil {
+		return params, fmt.Errorf("parametersRef %s '%s/%s' not found", ref.Kind, *ref.Namespace, ref.Name)
+	}
+	switch ref.Kind {
+	case K8S_DEFAULTS_CR_KIND:
+		params.defaults = ns.CRs.Defaults[ref.Name]
+		ok = params.defaults != nil
+	case K8S_BACKEND_CR_KIND:
+		params.backend = ns.CRs.Backends[ref.Name]
+		ok = params.backend != nil
+	case K8S_GLOBAL_CR_KIND:
+		return params, fmt.Errorf("parametersRef kind '%s' is not supported: global settings apply to the whole HAProxy process and are set with the 'cr-global' annotation", ref.Kind)
+	default:
+		return params, fmt.Errorf("parametersRef kind '%s' is not supported, only '%s' and '%s' are", ref.Kind, K8S_DEFAULTS_CR_KIND, K8S_BACKEND_CR_KIND)
+	}
+	if !ok {
+		return params, fmt.Errorf("parametersRef %s '%s/%s' not found", ref.Kind, *ref.Namespace, ref.Name)
+	}
+	return params, nil
+}
+
+// getListenerParameters provides the parameters of the gatewayclass of the gateway of the listener.
+func (gm GatewayManagerImpl) getListenerParameters(listener store.Listener) gatewayClassParameters {
+	ns, ok := gm.k8sStore.Namespaces[listener.GwNamespace]
+	if !ok {
+		return gatewayClassParameters{}
+	}
+	gw, ok := ns.Gateways[listener.GwName]
+	if !ok || gw == nil {
+		return gatewayClassParameters{}
+	}
+	return gm.paramsByClass[gw.GatewayClassName]
+}
+
+// getListenerBackendCR provides the '<namespace>/<name>' path of the Backend CR referenced by the parametersRef
+// of the gatewayclass of the gateway of the listener, empty if there is none.
+func (gm GatewayManagerImpl) getListenerBackendCR(listener store.Listener) string {
+	if gm.getListenerParameters(listener).backend == nil {
+		return ""
+	}
+	gw := gm.k8sStore.Namespaces[listener.GwNamespace].Gateways[listener.GwName]
+	ref := gm.k8sStore.GatewayClasses[gw.GatewayClassName].ParametersRef
+	return *ref.Namespace + "/" + ref.Name
+}
+
+// createRouteBackend creates or updates the backend of a route with the parameters of the gatewayclass of its first listener.
+func (gm GatewayManagerImpl) createRouteBackend(backendName, mode string, listeners []store.Listener) {
+	var params gatewayClassParameters
+	if len(listeners) != 0 {
+		params = gm.getListenerParameters(listeners[0])
+	}
+	gm.haproxyClient.BackendCreateOrUpdate(params.backendBase(backendName, mode))
+}
+
+// fingerprint provides a stable representation of the parameters to detect their modifications.
+func (params gatewayClassParameters) fingerprint() string {
+	if params.defaults == nil && params.backend == nil {
+		return ""
+	}
+	data, err := json.Marshal(params)
+	if err != nil {
+		logger.Error(err)
+		return ""
+	}
+	return string(data)
+}
+
+// MarshalJSON allows to fingerprint the unexported fields of the parameters.
+func (params gatewayClassParameters) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Defaults *models.Defaults `json:"defaults,omitempty"`
+		Backend  *v3.BackendSpec  `json:"backend,omitempty"`
+	}{params.defaults, params.backend})
+}
+
+// applyToFrontend sets on the frontend the client side settings of the Defaults CR.
+func (params gatewayClassParameters) applyToFrontend(frontend *models.FrontendBase) {
+	if params.defaults == nil {
+		return
+	}
+	defaults := params.defaults.DefaultsBase
+	frontend.ClientTimeout = defaults.ClientTimeout
+	frontend.ClientFinTimeout = defaults.ClientFinTimeout
+	frontend.Maxconn = defaults.Maxconn
+	if defaults.LogFormat != "" {
+		frontend.LogFormat = defaults.LogFormat
+	}
+	if frontend.Mode == "http" {
+		frontend.HTTPKeepAliveTimeout = defaults.HTTPKeepAliveTimeout
+		frontend.HTTPRequestTimeout = defaults.HTTPRequestTimeout
+		frontend.Forwardfor = defaults.Forwardfor
+	}
+}
+
+// backendBase provides the backend of a route built from the Backend CR and completed with the server side settings of the Defaults CR.
+func (params gatewayClassParameters) backendBase(backendName, mode string) models.BackendBase {
+	backend := models.BackendBase{}
+	if params.backend != nil {
+		backend = params.backend.BackendBase
+	}
+	backend.From = constants.DefaultsSectionName
+	backend.Name = backendName
+	backend.Mode = mode
+	if params.defaults != nil {
+		defaults := params.defaults.DefaultsBase
+		if backend.Balance == nil {
+			backend.Balance = defaults.Balance
+		}
+		if backend.DefaultServer == nil {
+			backend.DefaultServer = defaults.DefaultServer
+		}
+		if backend.ConnectTimeout == nil {
+			backend.ConnectTimeout = defaults.ConnectTimeout
+		}
+		if backend.ServerTimeout == nil {
+			backend.ServerTimeout = defaults.ServerTimeout
+		}
+		if backend.ServerFinTimeout == nil {
+			backend.ServerFinTimeout = defaults.ServerFinTimeout
+		}
+		if backend.QueueTimeout == nil {
+			backend.QueueTimeout = defaults.QueueTimeout
+		}
+		if backend.CheckTimeout == nil {
+			backend.CheckTimeout = defaults.CheckTimeout
+		}
+		if backend.TunnelTimeout == nil {
+			backend.TunnelTimeout = defaults.TunnelTimeout
+		}
+		if backend.Retries == nil {
+			backend.Retries = defaults.Retries
+		}
+		if backend.Redispatch == nil {
+			backend.Redispatch = defaults.Redispatch
+		}
+	}
+	if backend.DefaultServer == nil {
+		backend.DefaultServer = &models.DefaultServer{ServerParams: models.ServerParams{Check: "enabled"}}
+	}
+	if mode != "http" {
+		return tcpBackendBase(backend)
+	}
+	return backend
+}
+
+// tcpBackendBase returns the settings of the backend which are valid in tcp mode, http settings being rejected by HAProxy.
+// Only the settings known to be mode neutral are kept, so that new http settings of the Backend CR are not copied by mistake.
+func tcpBackendBase(backend models.BackendBase) models.BackendBase {
+	tcpBackend := models.BackendBase{
+		Allbackups:              backend.Allbackups,
+		CheckTimeout:            backend.CheckTimeout,
+		ConnectTimeout:          backend.ConnectTimeout,
+		DefaultServer:           backend.DefaultServer,
+		Description:             backend.Description,
+		EmailAlert:              backend.EmailAlert,
+		ExternalCheck:           backend.ExternalCheck,
+		ExternalCheckCommand:    backend.ExternalCheckCommand,
+		ExternalCheckPath:       backend.ExternalCheckPath,
+		From:                    backend.From,
+		Fullconn:                backend.Fullconn,
+		HashBalanceFactor:       backend.HashBalanceFactor,
+		HashType:                backend.HashType,
+		LoadServerStateFromFile: backend.LoadServerStateFromFile,
+		LogHealthChecks:         backend.LogHealthChecks,
+		LogTag:                  backend.LogTag,
+		Mode:                    backend.Mode,
+		MysqlCheckParams:        backend.MysqlCheckParams,
+		Name:                    backend.Name,
+		Nolinger:                backend.Nolinger,
+		PgsqlCheckParams:        backend.PgsqlCheckParams,
+		PreferLastServer:        backend.PreferLastServer,
+		QueueTimeout:            backend.QueueTimeout,
+		Redispatch:              backend.Redispatch,
+		Retries:                 backend.Retries,
+		ServerFinTimeout:        backend.ServerFinTimeout,
+		ServerStateFileName:     backend.ServerStateFileName,
+		ServerTimeout:           backend.ServerTimeout,
+		SmtpchkParams:           backend.SmtpchkParams,
+		Source:                  backend.Source,
+		SpliceAuto:              backend.SpliceAuto,
+		SpliceRequest:           backend.SpliceRequest,
+		SpliceResponse:          backend.SpliceResponse,
+		Srvtcpka:                backend.Srvtcpka,
+		SrvtcpkaCnt:             backend.SrvtcpkaCnt,
+		SrvtcpkaIdle:            backend.SrvtcpkaIdle,
+		SrvtcpkaIntvl:           backend.SrvtcpkaIntvl,
+		StickTable:              backend.StickTable,
+		TCPSmartConnect:         backend.TCPSmartConnect,
+		Tcpka:                   backend.Tcpka,
+		Transparent:             backend.Transparent,
+		TunnelTimeout:           backend.TunnelTimeout,
+	}
+	// Algorithms based on the request are http only.
+	if balance := backend.Balance; balance != nil && balance.Algorithm != nil {
+		switch *balance.Algorithm {
+		case models.BalanceAlgorithmHdr, models.BalanceAlgorithmURI, models.BalanceAlgorithmURLParam:
+		default:
+			tcpBackend.Balance = balance
+		}
+	}
+	return tcpBackend
+}
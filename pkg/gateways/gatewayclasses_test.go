@@ -0,0 +1,48 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gateway
+
+import (
+	"testing"
+
+	"github.com/haproxytech/client-native/v6/models"
+
+	v3 "github.com/haproxytech/kubernetes-ingress/crs/api/ingress/v3"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+func TestBackendBaseTCP(t *testing.T) {
+	params := gatewayClassParameters{
+		backend: &v3.BackendSpec{Backend: models.Backend{BackendBase: models.BackendBase{
+			Balance:            &models.Balance{Algorithm: utils.Ptr(models.BalanceAlgorithmURI)},
+			Compression:        &models.Compression{Algorithms: []string{"gzip"}},
+			Cookie:             &models.Cookie{Name: utils.Ptr("SRV")},
+			HTTPRequestTimeout: utils.PtrInt64(5000),
+			RetryOn:            "503",
+			ServerTimeout:      utils.PtrInt64(30000),
+		}}},
+	}
+	tcp := params.backendBase("ns_route_0", "tcp")
+	if tcp.Balance != nil || tcp.Compression != nil || tcp.Cookie != nil || tcp.HTTPRequestTimeout != nil || tcp.RetryOn != "" {
+		t.Errorf("backendBase() = %+v, want no http settings in a tcp backend", tcp)
+	}
+	if tcp.ServerTimeout == nil || *tcp.ServerTimeout != 30000 || tcp.Mode != "tcp" || tcp.Name != "ns_route_0" || tcp.DefaultServer == nil {
+		t.Errorf("backendBase() = %+v, want the mode neutral settings kept", tcp)
+	}
+	http := params.backendBase("ns_route_0", "http")
+	if http.Balance == nil || http.Compression == nil || http.Cookie == nil || http.HTTPRequestTimeout == nil {
+		t.Errorf("backendBase() = %+v, want the http settings kept in an http backend", http)
+	}
+}
@@ -16,13 +16,25 @@ package gateway
 
 import (
 	"fmt"
+	"math"
+	"net"
+	"os"
+	"path/filepath"
 	"sort"
+	"strings"
+
+	"github.com/google/renameio"
 
 	"github.com/haproxytech/client-native/v6/models"
-	"github.com/haproxytech/kubernetes-ingress/pkg/controller/constants"
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/fs"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/api"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/certs"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/instance"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/maps"
 	"github.com/haproxytech/kubernetes-ingress/pkg/k8s"
+	"github.com/haproxytech/kubernetes-ingress/pkg/route"
 	"github.com/haproxytech/kubernetes-ingress/pkg/store"
 	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
 	networkingv1 "k8s.io/api/networking/v1"
@@ -43,7 +55,11 @@ const (
 	K8S_NETWORKING_GROUP = networkingv1.GroupName
 	K8S_GATEWAY_GROUP    = v1beta1.GroupName
 	K8S_TCPROUTE_KIND    = "TCPRoute"
+	K8S_HTTPROUTE_KIND   = "HTTPRoute"
+	K8S_TLSROUTE_KIND    = "TLSRoute"
+	K8S_GATEWAY_KIND     = "Gateway"
 	K8S_SERVICE_KIND     = "Service"
+	K8S_SECRET_KIND      = "Secret"
 )
 
 //nolint:golint
@@ -53,33 +69,52 @@ type GatewayManager interface {
 }
 
 func New(k8sStore store.K8s,
-	haproxyClient api.HAProxyClient,
+	h haproxy.HAProxy,
+	a annotations.Annotations,
 	osArgs utils.OSArgs,
 	k8sRestClient client.Client,
 ) GatewayManager {
 	return &GatewayManagerImpl{
-		k8sStore:         k8sStore,
-		haproxyClient:    haproxyClient,
-		osArgs:           osArgs,
-		frontends:        map[string]struct{}{},
-		gateways:         map[string]struct{}{},
-		statusManager:    NewStatusManager(k8sRestClient, k8sStore.GatewayControllerName),
-		listenersByRoute: make(map[string][]store.Listener),
-		backends:         map[string]struct{}{},
-		serversByBackend: map[string][]string{},
+		k8sStore:          k8sStore,
+		haproxyClient:     h.HAProxyClient,
+		annotations:       a,
+		maps:              h.Maps,
+		certificates:      h.Certificates,
+		certsDir:          h.Env.Certs.MainDir,
+		osArgs:            osArgs,
+		frontends:         map[string]struct{}{},
+		gateways:          map[string]struct{}{},
+		statusManager:     NewStatusManager(k8sRestClient, k8sStore.GatewayControllerName),
+		listenersByRoute:  make(map[string][]store.Listener),
+		backends:          map[string]struct{}{},
+		serversByBackend:  map[string][]string{},
+		rulesByFrontend:   map[string][]string{},
+		crtLists:          map[string]string{},
+		paramsByClass:     map[string]gatewayClassParameters{},
+		paramsFingerprint: map[string]string{},
+		invalidParams:     map[string]string{},
 	}
 }
 
 //nolint:golint
 type GatewayManagerImpl struct {
 	haproxyClient       api.HAProxyClient
+	annotations         annotations.Annotations
+	maps                maps.Maps
+	certificates        certs.Certificates
 	statusManager       StatusManager
 	frontends           map[string]struct{}
 	gateways            map[string]struct{}
 	listenersByRoute    map[string][]store.Listener
 	backends            map[string]struct{}
 	serversByBackend    map[string][]string
+	rulesByFrontend     map[string][]string
+	crtLists            map[string]string
+	paramsByClass       map[string]gatewayClassParameters
+	paramsFingerprint   map[string]string
+	invalidParams       map[string]string
 	k8sStore            store.K8s
+	certsDir            string
 	osArgs              utils.OSArgs
 	gatewayAPIInstalled bool
 }
@@ -93,6 +128,8 @@ func (gm GatewayManagerImpl) ManageGateway() {
 
 	gm.manageListeners()
 	gm.manageTCPRoutes()
+	gm.manageHTTPRoutes()
+	gm.manageTLSRoutes()
 
 	gm.statusManager.ProcessStatuses()
 	gm.resetStatuses()
@@ -126,7 +163,15 @@ func (gm *GatewayManagerImpl) manageListeners() {
 			gwManaged := gwcfound && gwc.ControllerName == gm.k8sStore.GatewayControllerName
 			if gwManaged && gw.Status != store.DELETED {
 				gm.statusManager.PrepareGatewayStatus(*gw)
-				logger.Error(gm.createAllListeners(*gw))
+				addresses, errAddresses := gm.getGatewayAddresses(*gw)
+				gm.statusManager.SetGatewayAddresses(addresses)
+				if errAddresses != nil {
+					// Listeners can't be bound on the requested addresses so they're not created.
+					gm.statusManager.SetGatewayReasonAddressNotAssigned(errAddresses.Error())
+					logger.Errorf("gwapi: gateway '%s/%s': %s", gw.Namespace, gw.Name, errAddresses)
+				} else {
+					logger.Error(gm.createAllListeners(*gw))
+				}
 			}
 			_, gwConfigured := gm.gateways[gwName]
 			instance.ReloadIf(!((!gwConfigured && !gwManaged) || (gwConfigured && gwManaged && gw.Status == store.EMPTY)),
@@ -141,6 +186,7 @@ func (gm *GatewayManagerImpl) manageListeners() {
 			}
 		}
 	}
+	gm.deleteUnusedCrtLists()
 }
 
 // manageTCPRoutes creates backends from tcproutes and attaches them to corresponding frontends according attachment rules.
@@ -170,7 +216,7 @@ func (gm GatewayManagerImpl) manageTCPRoutes() {
 			gm.statusManager.PrepareTCPRouteStatusRecord(*tcproute)
 
 			// Get the list of listeners (frontends) this tcproute (set of backends) wants to be attached to.
-			listeners, errListeners := gm.getOurListenersFromTCPRoute(*tcproute)
+			listeners, errListeners := gm.getOurListenersFromRoute(K8S_TCPROUTE_KIND, tcproute.Namespace, tcproute.Name, tcproute.ParentRefs, nil)
 			logger.Error(errListeners)
 			for _, listener := range listeners {
 				frontendName := getFrontendName(listener)
@@ -204,20 +250,13 @@ func (gm GatewayManagerImpl) manageTCPRoutes() {
 			}
 
 			// If not called on the route, the afferent backend will be automatically deleted.
-			gm.haproxyClient.BackendCreateIfNotExist(
-				models.BackendBase{
-					From:          constants.DefaultsSectionName,
-					Name:          getBackendName(*tcproute),
-					Mode:          "tcp",
-					DefaultServer: &models.DefaultServer{ServerParams: models.ServerParams{Check: "enabled"}},
-				},
-			)
+			gm.createRouteBackend(tcpRouteBackendName, "tcp", listeners)
 
 			_, backendExists := gm.backends[tcpRouteBackendName]
 			instance.ReloadIf(!backendExists, "modification in backend for tcproute '%s/%s'", tcproute.Namespace, tcproute.Name)
 			gm.backends[tcpRouteBackendName] = struct{}{}
 			// Adds the servers to the backends
-			reloadServers, errServers := gm.addServersToRoute(*tcproute)
+			reloadServers, errServers := gm.addServersToBackend(tcpRouteBackendName, K8S_TCPROUTE_KIND, tcproute.Namespace, tcproute.Name, tcproute.BackendRefs)
 			instance.ReloadIf(reloadServers, "modification in servers of backend '%s' from tcproute '%s/%s'", tcpRouteBackendName, tcproute.Namespace, tcproute.Name)
 			logger.Error(errServers)
 		}
@@ -233,25 +272,39 @@ func (gm GatewayManagerImpl) manageTCPRoutes() {
 	}
 }
 
-// createAllListeners creates all TCP frontends from gateway and their bindings.
+// createAllListeners creates all TCP, HTTP, HTTPS and TLS frontends from gateway and their bindings.
 func (gm GatewayManagerImpl) createAllListeners(gateway store.Gateway) error {
 	var errs utils.Errors
 MAIN_LOOP:
 	for _, listener := range gateway.Listeners {
 		gm.statusManager.PrepareListenerStatus(listener)
-		if listener.Protocol != store.TCPProtocolType {
+		routeKind, supported := getRouteKindFromProtocol(listener.Protocol)
+		if !supported {
 			gm.statusManager.SetListenerReasonUnsupportedProtocol(fmt.Sprintf("Listener protocol '%s' is not supported", listener.Protocol))
 			continue
 		}
+		tlsMode := ""
+		if listener.TLS != nil {
+			tlsMode = listener.TLS.Mode
+		}
+		if listener.Protocol == store.HTTPSProtocolType && tlsMode != string(v1beta1.TLSModeTerminate) {
+			gm.statusManager.SetListenerReasonUnsupportedProtocol(fmt.Sprintf("Listener protocol '%s' is only supported with TLS mode '%s'", listener.Protocol, v1beta1.TLSModeTerminate))
+			continue
+		}
+		if listener.Protocol == store.TLSProtocolType && tlsMode == "" {
+			gm.statusManager.SetListenerReasonUnsupportedProtocol(fmt.Sprintf("Listener protocol '%s' requires a TLS configuration", listener.Protocol))
+			continue
+		}
+		tlsTerminate := listener.Protocol != store.HTTPProtocolType && tlsMode == string(v1beta1.TLSModeTerminate)
 		if listener.AllowedRoutes != nil {
 			validRGK := []store.RouteGroupKind{}
 			for _, kind := range listener.AllowedRoutes.Kinds {
-				if (kind.Group == nil || *kind.Group == v1alpha2.GroupName) && kind.Kind == K8S_TCPROUTE_KIND {
+				if (kind.Group == nil || *kind.Group == v1alpha2.GroupName) && kind.Kind == routeKind {
 					validRGK = append(validRGK, kind)
 				}
 			}
 			if len(validRGK) != len(listener.AllowedRoutes.Kinds) {
-				gm.statusManager.SetListenerReasonInvalidRouteKinds("Invalid Group/Kind in allowedRoutes: only gateway.networking.k8s.io or empty group and TCPRoute kind are supported", validRGK)
+				gm.statusManager.SetListenerReasonInvalidRouteKinds(fmt.Sprintf("Invalid Group/Kind in allowedRoutes: only gateway.networking.k8s.io or empty group and %s kind are supported", routeKind), validRGK)
 			}
 			if len(validRGK) == 0 && len(listener.AllowedRoutes.Kinds) != 0 {
 				continue MAIN_LOOP
@@ -259,18 +312,64 @@ MAIN_LOOP:
 		}
 
 		frontendName := getFrontendName(listener)
-		errFrontendCreate := gm.haproxyClient.FrontendCreate(models.FrontendBase{
+		bindParams := models.BindParams{}
+		if tlsTerminate {
+			certFiles := gm.getListenerCertificates(listener)
+			if len(certFiles) == 0 {
+				continue
+			}
+			bindParams.Ssl = true
+			bindParams.CrtList = gm.writeCrtList(frontendName, certFiles)
+		}
+		frontend := models.FrontendBase{
 			Name:   frontendName,
 			Mode:   "tcp",
 			Tcplog: true,
-		})
+		}
+		switch routeKind {
+		case K8S_HTTPROUTE_KIND:
+			frontend.Mode = "http"
+			frontend.Tcplog = false
+			frontend.Httplog = true
+		case K8S_TLSROUTE_KIND:
+			frontend.Tcplog = false
+			frontend.LogFormat = route.SNILogFormat
+		}
+		gm.getListenerParameters(listener).applyToFrontend(&frontend)
+		errFrontendCreate := gm.haproxyClient.FrontendCreate(frontend)
 		if errFrontendCreate != nil {
 			errs.Add(errFrontendCreate)
 			continue
 		}
 		gm.frontends[frontendName] = struct{}{}
+		if routeKind == K8S_TLSROUTE_KIND {
+			errSNIRules := gm.addSNIRulesToFrontend(frontendName, tlsTerminate)
+			if errSNIRules != nil {
+				errs.Add(errSNIRules)
+				continue
+			}
+		}
 		port := int64(listener.Port)
+		if len(gateway.Addresses) != 0 {
+			// Listeners are bound on the addresses requested by the gateway, validated by getGatewayAddresses.
+			for i, address := range gateway.Addresses {
+				bindParams.Name = fmt.Sprintf("addr%d", i)
+				errBinCreate := gm.haproxyClient.FrontendBindCreate(
+					frontendName, models.Bind{
+						Port:       &port,
+						Address:    address.Value,
+						BindParams: bindParams,
+					},
+				)
+				if errBinCreate != nil {
+					errs.Add(errBinCreate)
+					continue MAIN_LOOP
+				}
+			}
+			continue
+		}
 		if !gm.osArgs.DisableIPV4 {
+			bindParams.Name = "v4"
 			errBinCreate := gm.haproxyClient.FrontendBindCreate(
 				frontendName, models.Bind{
 					Port: &port,
@@ -280,7 +379,7 @@ MAIN_LOOP:
 						}
 						return "0.0.0.0"
 					}(),
-					BindParams: models.BindParams{Name: "v4"},
+					BindParams: bindParams,
 				},
 			)
 			if errBinCreate != nil {
@@ -289,6 +388,7 @@ MAIN_LOOP:
 			}
 		}
 		if !gm.osArgs.DisableIPV6 {
+			bindParams.Name = "v6"
 			errBinCreate := gm.haproxyClient.FrontendBindCreate(
 				frontendName, models.Bind{
 					Port: &port,
@@ -298,7 +398,7 @@ MAIN_LOOP:
 						}
 						return ":::"
 					}(),
-					BindParams: models.BindParams{Name: "v6"},
+					BindParams: bindParams,
 				},
 			)
 			if errBinCreate != nil {
@@ -330,7 +430,7 @@ func (gm GatewayManagerImpl) isBackendRefValid(backendRef store.BackendRef) bool
 
 // isNamespaceGranted checks that backendref can refer to a resource.
 // This check depends on cross namespace reference and authorization to do so by referenceGrant if necessary.
-func (gm GatewayManagerImpl) isNamespaceGranted(namespace string, backendRef store.BackendRef) (granted bool) {
+func (gm GatewayManagerImpl) isNamespaceGranted(namespace, routeKind string, backendRef store.BackendRef) (granted bool) {
 	// If namespace of backendRef is specified ...
 	if backendRef.Namespace != nil && *backendRef.Namespace != namespace {
 		ns, found := gm.k8sStore.Namespaces[*backendRef.Namespace]
@@ -338,27 +438,7 @@ func (gm GatewayManagerImpl) isNamespaceGranted(namespace string, backendRef sto
 			gm.statusManager.SetRouteReasonBackendNotFound(fmt.Sprintf("backend '%s/%s' not found", utils.PointerDefaultValueIfNil(backendRef.Namespace), backendRef.Name))
 			return granted
 		}
-		// .. we iterate over referenceGrants in this namespace.
-		for _, referenceGrant := range ns.ReferenceGrants {
-			fromGranted := false
-			// If referenceGrant allows tcproutes from their namespace to be origin to attach ...
-			for _, from := range referenceGrant.From {
-				if from.Group == K8S_GATEWAY_GROUP && from.Kind == K8S_TCPROUTE_KIND && from.Namespace == namespace {
-					fromGranted = true
-					break
-				}
-			}
-			// ... then check if it allows the target backendRef which must be a service potentially named.
-			if fromGranted {
-				for _, to := range referenceGrant.To {
-					if to.Group == K8S_CORE_GROUP && to.Kind == K8S_SERVICE_KIND &&
-						(to.Name == nil || *to.Name == backendRef.Name) {
-						granted = true
-						break
-					}
-				}
-			}
-		}
+		granted = isReferenceGranted(ns, routeKind, namespace, K8S_SERVICE_KIND, backendRef.Name)
 		if !granted {
 			gm.statusManager.SetRouteReasonRefNotPermitted(fmt.Sprintf("backendref '%s/%s' not allowed by any referencegrant",
 				*backendRef.Namespace, backendRef.Name))
@@ -368,107 +448,301 @@ func (gm GatewayManagerImpl) isNamespaceGranted(namespace string, backendRef sto
 	return true
 }
 
-// addServersToRoute adds all the servers from the backendrefs from tcproute according validation rules.
-func (gm GatewayManagerImpl) addServersToRoute(route store.TCPRoute) (reload bool, err error) {
-	backendName := getBackendName(route)
-	_ = gm.haproxyClient.BackendServerDeleteAll(backendName)
-	i := 0
-	var servers []string
-	defer func() {
-		previousServers := gm.serversByBackend[backendName]
-		reload = reload || !utils.EqualSliceStringsWithoutOrder(servers, previousServers)
-		gm.serversByBackend[backendName] = servers
-	}()
-	for id, backendRef := range route.BackendRefs {
-		if !gm.isBackendRefValid(backendRef) {
-			continue
+// getGatewayAddresses provides the addresses of the gateway for its status.
+// If the gateway doesn't request any address, its addresses are the ones of the publish service.
+// Otherwise only IP addresses of enabled IP families can be requested.
+func (gm GatewayManagerImpl) getGatewayAddresses(gateway store.Gateway) ([]store.GatewayAddress, error) {
+	addresses := []store.GatewayAddress{}
+	if len(gateway.Addresses) == 0 {
+		for _, address := range gm.k8sStore.PublishServiceAddresses {
+			addressType := string(v1beta1.IPAddressType)
+			if net.ParseIP(address) == nil {
+				addressType = string(v1beta1.HostnameAddressType)
+			}
+			addresses = append(addresses, store.GatewayAddress{Type: &addressType, Value: address})
 		}
+		return addresses, nil
+	}
+	for _, address := range gateway.Addresses {
+		if address.Type != nil && *address.Type != string(v1beta1.IPAddressType) {
+			return nil, fmt.Errorf("address '%s' of type '%s' not supported", address.Value, *address.Type)
+		}
+		ip := net.ParseIP(address.Value)
+		switch {
+		case ip == nil:
+			return nil, fmt.Errorf("invalid IP address '%s'", address.Value)
+		case ip.To4() != nil && gm.osArgs.DisableIPV4:
+			return nil, fmt.Errorf("IP address '%s' can't be assigned, IPv4 is disabled", address.Value)
+		case ip.To4() == nil && gm.osArgs.DisableIPV6:
+			return nil, fmt.Errorf("IP address '%s' can't be assigned, IPv6 is disabled", address.Value)
+		}
+		addressType := string(v1beta1.IPAddressType)
+		addresses = append(addresses, store.GatewayAddress{Type: &addressType, Value: address.Value})
+	}
+	return addresses, nil
+}
 
-		if !gm.isNamespaceGranted(route.Namespace, backendRef) {
-			gm.statusManager.SetRouteReasonRefNotPermitted(fmt.Sprintf("backend '%s/%s' reference not allowed", utils.PointerDefaultValueIfNil(backendRef.Namespace), backendRef.Name))
-			continue
+// isReferenceGranted checks if a referenceGrant in the namespace of the target allows the resources of the kind
+// from their namespace to refer to the target which must be a core resource of the kind potentially named.
+func isReferenceGranted(targetNs *store.Namespace, fromKind, fromNamespace, toKind, toName string) bool {
+	// We iterate over referenceGrants in the namespace of the target.
+	for _, referenceGrant := range targetNs.ReferenceGrants {
+		fromGranted := false
+		// If referenceGrant allows resources of this kind from their namespace to be origin of the reference ...
+		for _, from := range referenceGrant.From {
+			if from.Group == K8S_GATEWAY_GROUP && from.Kind == fromKind && from.Namespace == fromNamespace {
+				fromGranted = true
+				break
+			}
+		}
+		// ... then check if it allows the target.
+		if fromGranted {
+			for _, to := range referenceGrant.To {
+				if to.Group == K8S_CORE_GROUP && to.Kind == toKind &&
+					(to.Name == nil || *to.Name == toName) {
+					return true
+				}
+			}
 		}
+	}
+	return false
+}
 
-		nsBackendRef := backendRef.Namespace
-		if nsBackendRef == nil {
-			nsBackendRef = &route.Namespace
+// getListenerCertificates loads the secrets of the certificateRefs of the listener and provides the paths of the certificates.
+// Invalid or not permitted references are reported in the listener status.
+func (gm GatewayManagerImpl) getListenerCertificates(listener store.Listener) (certFiles []string) {
+	if len(listener.TLS.CertificateRefs) == 0 {
+		gm.statusManager.SetListenerReasonInvalidCertificateRef("no certificateRefs provided")
+		return certFiles
+	}
+	for _, certificateRef := range listener.TLS.CertificateRefs {
+		if (certificateRef.Group != nil && *certificateRef.Group != K8S_CORE_GROUP) ||
+			(certificateRef.Kind != nil && *certificateRef.Kind != K8S_SECRET_KIND) {
+			gm.statusManager.SetListenerReasonInvalidCertificateRef(fmt.Sprintf("certificateRef '%s' of group '%s' and kind '%s' not managed",
+				certificateRef.Name, utils.PointerDefaultValueIfNil(certificateRef.Group), utils.PointerDefaultValueIfNil(certificateRef.Kind)))
+			continue
 		}
-		ns, found := gm.k8sStore.Namespaces[*nsBackendRef]
-		if !found {
-			gm.statusManager.SetRouteReasonBackendNotFound(fmt.Sprintf("backend '%s/%s' not found", *nsBackendRef, backendRef.Name))
-			logger.Errorf("gwapi: unexisting namespace '%s' for backendRef number '%d' from tcp route '%s/%s'", *nsBackendRef, id, route.Namespace, route.Name)
+		namespace := listener.GwNamespace
+		if certificateRef.Namespace != nil && *certificateRef.Namespace != listener.GwNamespace {
+			namespace = *certificateRef.Namespace
+			ns, found := gm.k8sStore.Namespaces[namespace]
+			if !found || !isReferenceGranted(ns, K8S_GATEWAY_KIND, listener.GwNamespace, K8S_SECRET_KIND, certificateRef.Name) {
+				gm.statusManager.SetListenerReasonRefNotPermitted(fmt.Sprintf("certificateRef '%s/%s' not allowed by any referencegrant", namespace, certificateRef.Name))
+				continue
+			}
+		}
+		secret, err := gm.k8sStore.GetSecret(namespace, certificateRef.Name)
+		if err != nil {
+			gm.statusManager.SetListenerReasonInvalidCertificateRef(err.Error())
 			continue
 		}
-		service, found := ns.Services[backendRef.Name]
-		if !found {
-			gm.statusManager.SetRouteReasonBackendNotFound(fmt.Sprintf("backend '%s/%s' not found", *nsBackendRef, backendRef.Name))
-			logger.Errorf("gwapi: unexisting endpoints '%s' for backendRef number '%d' from tcp route '%s/%s'", backendRef.Name, id, route.Namespace, route.Name)
+		certFile, err := gm.certificates.AddSecret(secret, certs.TCP_CERT)
+		if err != nil {
+			gm.statusManager.SetListenerReasonInvalidCertificateRef(fmt.Sprintf("certificateRef '%s/%s': %s", namespace, certificateRef.Name, err))
 			continue
 		}
-		var portName *string
-		backendRefPort := int64(*backendRef.Port)
-		for _, svcPort := range service.Ports {
-			if svcPort.Port == backendRefPort {
-				svcPortName := svcPort.Name
-				portName = &svcPortName
-				break
-			}
+		certFiles = append(certFiles, certFile)
+	}
+	return certFiles
+}
+
+// writeCrtList provides the crt-list of the TLS terminating frontend made of the certificates.
+// The crt-list is only written to disk prior to the reload required by a change of its content.
+func (gm GatewayManagerImpl) writeCrtList(frontendName string, certFiles []string) string {
+	crtList := filepath.Join(gm.certsDir, frontendName+".crt-list")
+	content := strings.Join(certFiles, "\n") + "\n"
+	if gm.crtLists[frontendName] != content {
+		instance.Reload("modification in certificates of frontend '%s'", frontendName)
+		gm.crtLists[frontendName] = content
+		fs.AddDelayedFunc(crtList, func() {
+			logger.Error(renameio.WriteFile(crtList, []byte(content), 0o666))
+		})
+	}
+	return crtList
+}
+
+// deleteUnusedCrtLists removes the crt-lists of the frontends which are no longer TLS terminating.
+func (gm GatewayManagerImpl) deleteUnusedCrtLists() {
+	for frontendName := range gm.crtLists {
+		if gm.isTLSTerminating(frontendName) {
+			continue
+		}
+		crtList := filepath.Join(gm.certsDir, frontendName+".crt-list")
+		fs.AddDelayedFunc(crtList, func() {
+			logger.Error(os.Remove(crtList))
+		})
+		delete(gm.crtLists, frontendName)
+	}
+}
+
+// isTLSTerminating checks if the frontend exists and deciphers TLS.
+func (gm GatewayManagerImpl) isTLSTerminating(frontendName string) bool {
+	frontend, err := gm.haproxyClient.FrontendGet(frontendName)
+	if err != nil {
+		return false
+	}
+	for _, bind := range frontend.Binds {
+		if bind.Ssl {
+			return true
 		}
-		if portName == nil {
-			gm.statusManager.SetRouteReasonBackendNotFound(fmt.Sprintf("backend port '%s/%s' not found", *nsBackendRef, backendRef.Name))
-			//revive:disable-next-line:line-length-limit
-			logger.Errorf("gwapi: unexisting port '%d' for backendRef '%spkg/gateways/gateways.go' number '%d' from tcp route '%s/%s'", backendRefPort, backendRef.Name, id, route.Namespace, route.Name)
+	}
+	return false
+}
+
+// backendRefServers are the servers of the endpoints of a backendRef.
+type backendRefServers struct {
+	addresses []string
+	ports     []int64
+	weight    int32
+}
+
+// addServersToBackend adds all the servers from the backendrefs of a tcproute or tlsroute to the backend according validation rules.
+// The traffic is split between backendRefs according their weights, whatever their number of endpoints.
+func (gm GatewayManagerImpl) addServersToBackend(backendName, routeKind, routeNamespace, routeName string, backendRefs []store.BackendRef) (reload bool, err error) {
+	_ = gm.haproxyClient.BackendServerDeleteAll(backendName)
+	var servers []string
+	defer func() {
+		previousServers := gm.serversByBackend[backendName]
+		reload = reload || !utils.EqualSliceStringsWithoutOrder(servers, previousServers)
+		gm.serversByBackend[backendName] = servers
+	}()
+	serversByBackendRef := []backendRefServers{}
+	for id, backendRef := range backendRefs {
+		service, portName, ok := gm.getBackendRefService(routeKind, routeNamespace, routeName, id, backendRef)
+		if !ok {
 			continue
 		}
-		slice, found := ns.Endpoints[backendRef.Name]
+		slice, found := gm.k8sStore.Namespaces[service.Namespace].Endpoints[backendRef.Name]
 		if !found {
-			gm.statusManager.SetRouteReasonBackendNotFound(fmt.Sprintf("backend '%s/%s' not found", *nsBackendRef, backendRef.Name))
-			logger.Errorf("gwapi: unexisting endpoints '%s' for backendRef number '%d' from tcp route '%s/%s'", backendRef.Name, id, route.Namespace, route.Name)
+			gm.statusManager.SetRouteReasonBackendNotFound(fmt.Sprintf("backend '%s/%s' not found", service.Namespace, backendRef.Name))
+			logger.Errorf("gwapi: unexisting endpoints '%s' for backendRef number '%d' from route '%s/%s'", backendRef.Name, id, routeNamespace, routeName)
 			continue
 		}
 
+		refServers := backendRefServers{weight: 1}
+		if backendRef.Weight != nil {
+			refServers.weight = *backendRef.Weight
+		}
 		for _, endpoints := range slice {
 			if endpoints.Status == store.DELETED {
 				continue
 			}
-			if port, found := endpoints.Ports[*portName]; found {
+			if port, found := endpoints.Ports[portName]; found {
 				for address := range port.Addresses {
-					servers = append(servers, fmt.Sprintf("%s:%d", address, port.Port))
-					err = gm.haproxyClient.BackendServerCreate(backendName, models.Server{
-						Address:      address,
-						Port:         &port.Port,
-						Name:         fmt.Sprintf("SRV_%d", i+1),
-						ServerParams: models.ServerParams{Maintenance: "disabled"},
-					})
-					if err != nil {
-						return reload, err
-					}
-					i++
+					refServers.addresses = append(refServers.addresses, address)
+					refServers.ports = append(refServers.ports, port.Port)
 				}
 			}
 		}
+		serversByBackendRef = append(serversByBackendRef, refServers)
+	}
+
+	weights := getServersWeights(serversByBackendRef)
+	i := 0
+	for refIndex, refServers := range serversByBackendRef {
+		for j, address := range refServers.addresses {
+			servers = append(servers, fmt.Sprintf("%s:%d/%d", address, refServers.ports[j], weights[refIndex]))
+			err = gm.haproxyClient.BackendServerCreate(backendName, models.Server{
+				Address: address,
+				Port:    &refServers.ports[j],
+				Name:    fmt.Sprintf("SRV_%d", i+1),
+				ServerParams: models.ServerParams{
+					Maintenance: "disabled",
+					Weight:      utils.PtrInt64(weights[refIndex]),
+				},
+			})
+			if err != nil {
+				return reload, err
+			}
+			i++
+		}
 	}
 	return reload, err
 }
 
-// getOurListenersFromTCPRoute computes the list of listeners the tcproute can be attached to according matching and authorizations rules.
-func (gm GatewayManagerImpl) getOurListenersFromTCPRoute(tcproute store.TCPRoute) ([]store.Listener, error) {
+// getBackendRefService returns the service of a backendRef of a route and the name of its port.
+// The backendRef can't be used when it's not valid, not granted or not found, the reason is then set in the route status.
+func (gm GatewayManagerImpl) getBackendRefService(routeKind, routeNamespace, routeName string, id int, backendRef store.BackendRef) (service *store.Service, portName string, ok bool) {
+	if !gm.isBackendRefValid(backendRef) {
+		return nil, "", false
+	}
+
+	if !gm.isNamespaceGranted(routeNamespace, routeKind, backendRef) {
+		gm.statusManager.SetRouteReasonRefNotPermitted(fmt.Sprintf("backend '%s/%s' reference not allowed", utils.PointerDefaultValueIfNil(backendRef.Namespace), backendRef.Name))
+		return nil, "", false
+	}
+
+	nsBackendRef := backendRef.Namespace
+	if nsBackendRef == nil {
+		nsBackendRef = &routeNamespace
+	}
+	ns, found := gm.k8sStore.Namespaces[*nsBackendRef]
+	if !found {
+		gm.statusManager.SetRouteReasonBackendNotFound(fmt.Sprintf("backend '%s/%s' not found", *nsBackendRef, backendRef.Name))
+		logger.Errorf("gwapi: unexisting namespace '%s' for backendRef number '%d' from route '%s/%s'", *nsBackendRef, id, routeNamespace, routeName)
+		return nil, "", false
+	}
+	service, found = ns.Services[backendRef.Name]
+	if !found {
+		gm.statusManager.SetRouteReasonBackendNotFound(fmt.Sprintf("backend '%s/%s' not found", *nsBackendRef, backendRef.Name))
+		logger.Errorf("gwapi: unexisting endpoints '%s' for backendRef number '%d' from route '%s/%s'", backendRef.Name, id, routeNamespace, routeName)
+		return nil, "", false
+	}
+	backendRefPort := int64(*backendRef.Port)
+	for _, svcPort := range service.Ports {
+		if svcPort.Port == backendRefPort {
+			return service, svcPort.Name, true
+		}
+	}
+	gm.statusManager.SetRouteReasonBackendNotFound(fmt.Sprintf("backend port '%s/%s' not found", *nsBackendRef, backendRef.Name))
+	//revive:disable-next-line:line-length-limit
+	logger.Errorf("gwapi: unexisting port '%d' for backendRef '%s' number '%d' from route '%s/%s'", backendRefPort, backendRef.Name, id, routeNamespace, routeName)
+	return nil, "", false
+}
+
+// getServersWeights provides the HAProxy weight of the servers of every backendRef.
+// The weight of a backendRef is shared between its servers and the result is scaled to the
+// HAProxy weights range so that the most weighted server gets the maximum weight.
+// Servers of a backendRef with a weight of zero get a weight of zero and don't receive any traffic.
+func getServersWeights(serversByBackendRef []backendRefServers) []int64 {
+	const maxWeight = 256
+	weights := make([]int64, len(serversByBackendRef))
+	shares := make([]float64, len(serversByBackendRef))
+	var maxShare float64
+	for i, refServers := range serversByBackendRef {
+		if len(refServers.addresses) == 0 || refServers.weight <= 0 {
+			continue
+		}
+		shares[i] = float64(refServers.weight) / float64(len(refServers.addresses))
+		maxShare = math.Max(maxShare, shares[i])
+	}
+	for i, share := range shares {
+		if share == 0 {
+			continue
+		}
+		weights[i] = int64(math.Max(1, math.Round(share/maxShare*maxWeight)))
+	}
+	return weights
+}
+
+// getOurListenersFromRoute computes the list of listeners the route can be attached to according matching and authorizations rules.
+func (gm GatewayManagerImpl) getOurListenersFromRoute(routeKind, routeNamespace, routeName string, parentRefs []store.ParentRef, hostnames []string) ([]store.Listener, error) {
 	var errors utils.Errors
 	listeners := []store.Listener{}
 	// Iterates over parentRefs  which must be a gateway
-	for i, parentRef := range tcproute.ParentRefs {
-		gatewayNs := tcproute.Namespace
+	for i, parentRef := range parentRefs {
+		gatewayNs := routeNamespace
 		if parentRef.Namespace != nil {
 			gatewayNs = *parentRef.Namespace
 		}
 		ns, found := gm.k8sStore.Namespaces[gatewayNs]
 		if !found {
-			errors.Add(fmt.Errorf("gwapi: unexisting namespace '%s' in parentRef number '%d' from tcp route '%s/%s'", gatewayNs, i, tcproute.Namespace, tcproute.Name))
+			errors.Add(fmt.Errorf("gwapi: unexisting namespace '%s' in parentRef number '%d' from route '%s/%s'", gatewayNs, i, routeNamespace, routeName))
 			continue
 		}
 		gw, found := ns.Gateways[parentRef.Name]
 		if !found || gw == nil {
-			errors.Add(fmt.Errorf("gwapi: unexisting gateway in parentRef '%s' from tcp route '%s/%s'", parentRef.Name, tcproute.Namespace, tcproute.Name))
+			errors.Add(fmt.Errorf("gwapi: unexisting gateway in parentRef '%s' from route '%s/%s'", parentRef.Name, routeNamespace, routeName))
 			continue
 		}
 		if !gm.isGatewayManaged(*gw) || gw.Status == store.DELETED {
@@ -478,12 +752,20 @@ func (gm GatewayManagerImpl) getOurListenersFromTCPRoute(tcproute store.TCPRoute
 		// We found the gateway, let's see if there's a match.
 		hasSectionName := parentRef.SectionName != nil
 		for _, listener := range gw.Listeners {
-			// if listener.Protocol != store.TCPProtocolType || (hasSectionName && listener.Name != *parentRef.SectionName) {
 			if hasSectionName && listener.Name != *parentRef.SectionName {
 				continue
 			}
+			// Only listeners whose protocol carries this kind of route are candidates.
+			if listenerRouteKind, _ := getRouteKindFromProtocol(listener.Protocol); listenerRouteKind != routeKind {
+				continue
+			}
 			// Does listener allow the route to be attached ?
-			if !gm.isTCPRouteAllowedByListener(listener, tcproute.Namespace, gatewayNs, parentRef) {
+			if !gm.isRouteAllowedByListener(routeKind, listener, routeNamespace, gatewayNs, parentRef) {
+				continue
+			}
+			// Does the listener accept at least one of the route hostnames ?
+			if len(getListenerHostnames(listener, hostnames)) == 0 {
+				gm.statusManager.SetRouteReasonNoMatchingListenerHostname(fmt.Sprintf("no matching hostname with listener '%s/%s/%s'", listener.GwNamespace, listener.GwName, listener.Name), parentRef)
 				continue
 			}
 			// Does the listener have the expected name if provided ?
@@ -539,8 +821,8 @@ func (gm GatewayManagerImpl) isGatewayManaged(gateway store.Gateway) bool {
 	return gwc.ControllerName == gm.k8sStore.GatewayControllerName
 }
 
-// isTCPRouteAllowedByListener checks if the tcproute can refer to the listener according listener's authorization rules.
-func (gm GatewayManagerImpl) isTCPRouteAllowedByListener(listener store.Listener, routeNamespace, gatewayNamespace string, parentRef store.ParentRef) (allowed bool) {
+// isRouteAllowedByListener checks if the route can refer to the listener according listener's authorization rules.
+func (gm GatewayManagerImpl) isRouteAllowedByListener(routeKind string, listener store.Listener, routeNamespace, gatewayNamespace string, parentRef store.ParentRef) (allowed bool) {
 	defer func() {
 		if !allowed {
 			gm.statusManager.SetRouteReasonNotAllowedByListeners(fmt.Sprintf("not allowed by listener '%s/%s/%s'", listener.GwNamespace, listener.GwName, listener.Name), parentRef)
@@ -554,7 +836,7 @@ func (gm GatewayManagerImpl) isTCPRouteAllowedByListener(listener store.Listener
 
 	gkAllowed := len(listener.AllowedRoutes.Kinds) == 0
 	for _, kind := range listener.AllowedRoutes.Kinds {
-		if (kind.Group != nil && *kind.Group != v1alpha2.GroupName) || kind.Kind != K8S_TCPROUTE_KIND {
+		if (kind.Group != nil && *kind.Group != v1alpha2.GroupName) || kind.Kind != routeKind {
 			continue
 		}
 		gkAllowed = true
@@ -593,14 +875,17 @@ func (gm GatewayManagerImpl) isTCPRouteAllowedByListener(listener store.Listener
 	return true
 }
 
-// manageGatewayClass has the sole purpose of updating status for matching gatewayclasses.
-func (gm GatewayManagerImpl) manageGatewayClass() {
-	for _, gatewayclass := range gm.k8sStore.GatewayClasses {
-		if gatewayclass.ControllerName == gm.k8sStore.GatewayControllerName &&
-			(gatewayclass.Status == store.ADDED || gatewayclass.Status == store.MODIFIED) {
-			gm.statusManager.SetGatewayClassConditionStatusAccepted(*gatewayclass)
-		}
+// getRouteKindFromProtocol provides the kind of routes a listener with the given protocol can carry.
+func getRouteKindFromProtocol(protocol string) (routeKind string, supported bool) {
+	switch protocol {
+	case store.TCPProtocolType:
+		return K8S_TCPROUTE_KIND, true
+	case store.HTTPProtocolType, store.HTTPSProtocolType:
+		return K8S_HTTPROUTE_KIND, true
+	case store.TLSProtocolType:
+		return K8S_TLSROUTE_KIND, true
 	}
+	return "", false
 }
 
 // getBackendName provides backend name from tcproute attributes.
@@ -657,6 +942,32 @@ func (gm *GatewayManagerImpl) resetStatuses() {
 			}
 		}
 	}
+
+	// httproutes
+	for _, ns := range gm.k8sStore.Namespaces {
+		if !ns.Relevant {
+			logger.Debugf("gwapi: skipping namespace '%s'", ns.Name)
+			continue
+		}
+		for _, httproute := range ns.HTTPRoutes {
+			if httproute.Status == store.ADDED || httproute.Status == store.MODIFIED {
+				httproute.Status = store.EMPTY
+			}
+		}
+	}
+
+	// tlsroutes
+	for _, ns := range gm.k8sStore.Namespaces {
+		if !ns.Relevant {
+			logger.Debugf("gwapi: skipping namespace '%s'", ns.Name)
+			continue
+		}
+		for _, tlsroute := range ns.TLSRoutes {
+			if tlsroute.Status == store.ADDED || tlsroute.Status == store.MODIFIED {
+				tlsroute.Status = store.EMPTY
+			}
+		}
+	}
 }
 
 func (gm *GatewayManagerImpl) SetGatewayAPIInstalled(gatewayAPIInstalled bool) {
@@ -0,0 +1,210 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gateway
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/rules"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// HTTPRouteMatchVar is the transaction variable holding the index of the elected match when its rule has filters.
+const HTTPRouteMatchVar = "txn.gw_match"
+
+// checkHTTPRouteFilters returns an error if the filters of the httproute rule can't be configured.
+// Mirroring requests needs an external agent and extension references are not supported,
+// the rule is then not served and the error is reported in the Accepted condition of the route status.
+func checkHTTPRouteFilters(rule store.HTTPRouteRule) error {
+	for _, filter := range rule.Filters {
+		var path *store.HTTPPathModifier
+		switch filter.Type {
+		case string(v1beta1.HTTPRouteFilterRequestHeaderModifier):
+		case string(v1beta1.HTTPRouteFilterRequestRedirect):
+			if filter.RequestRedirect != nil {
+				path = filter.RequestRedirect.Path
+			}
+		case string(v1beta1.HTTPRouteFilterURLRewrite):
+			if filter.URLRewrite != nil {
+				path = filter.URLRewrite.Path
+			}
+		case string(v1beta1.HTTPRouteFilterRequestMirror):
+			return fmt.Errorf("filter type '%s' is not supported, HAProxy cannot mirror requests without an external agent", filter.Type)
+		default:
+			return fmt.Errorf("filter type '%s' is not supported", filter.Type)
+		}
+		if path == nil {
+			continue
+		}
+		value := ""
+		switch path.Type {
+		case string(v1beta1.FullPathHTTPPathModifier):
+			if path.ReplaceFullPath != nil {
+				value = *path.ReplaceFullPath
+			}
+		case string(v1beta1.PrefixMatchHTTPPathModifier):
+			if path.ReplacePrefixMatch != nil {
+				value = *path.ReplacePrefixMatch
+			}
+			for _, match := range rule.Matches {
+				if match.Path != nil && match.Path.Type != string(v1beta1.PathMatchPathPrefix) {
+					return fmt.Errorf("path modifier '%s' requires matches of type '%s'", path.Type, v1beta1.PathMatchPathPrefix)
+				}
+			}
+		default:
+			return fmt.Errorf("path modifier type '%s' is not supported", path.Type)
+		}
+		if strings.ContainsAny(value, " \t\"'\\#") {
+			return fmt.Errorf("path '%s' of filter '%s' contains unsupported characters", value, filter.Type)
+		}
+	}
+	return nil
+}
+
+// filterRules returns the HAProxy rules applying the filters of the rule of the match, in their order.
+// The scheme of redirections defaults to the one of the frontend.
+func (m httpRouteMatch) filterRules(tlsTerminate bool) []rules.Rule {
+	filterRules := []rules.Rule{}
+	for _, filter := range m.filters {
+		switch {
+		case filter.RequestHeaderModifier != nil:
+			for _, header := range filter.RequestHeaderModifier.Set {
+				filterRules = append(filterRules, rules.SetHdr{
+					HdrName:   header.Name,
+					HdrFormat: quoteLogFormat(header.Value),
+				})
+			}
+			for _, header := range filter.RequestHeaderModifier.Add {
+				filterRules = append(filterRules, rules.ReqAddHdr{
+					HdrName:   header.Name,
+					HdrFormat: quoteLogFormat(header.Value),
+				})
+			}
+			for _, name := range filter.RequestHeaderModifier.Remove {
+				filterRules = append(filterRules, rules.ReqDelHdr{
+					HdrName: name,
+				})
+			}
+		case filter.URLRewrite != nil:
+			if hostname := filter.URLRewrite.Hostname; hostname != nil {
+				filterRules = append(filterRules, rules.ReqSetHost{
+					Host: *hostname,
+				})
+			}
+			if rewrite := m.pathRewrite(filter.URLRewrite.Path); rewrite != nil {
+				filterRules = append(filterRules, *rewrite)
+			}
+		case filter.RequestRedirect != nil:
+			filterRules = append(filterRules, m.redirect(*filter.RequestRedirect, tlsTerminate))
+		}
+	}
+	return filterRules
+}
+
+// pathRewrite returns the rule replacing the full path or the prefix matched by the match.
+func (m httpRouteMatch) pathRewrite(path *store.HTTPPathModifier) *rules.ReqPathRewrite {
+	if path == nil {
+		return nil
+	}
+	if path.Type == string(v1beta1.FullPathHTTPPathModifier) {
+		return &rules.ReqPathRewrite{
+			PathMatch: ".*",
+			PathFmt:   escapeLogFormat(defaultPath(path.ReplaceFullPath)),
+		}
+	}
+	prefixLength, replacement := m.prefixReplacement(path)
+	if replacement == "" {
+		return &rules.ReqPathRewrite{
+			PathMatch: fmt.Sprintf("^.{%d}/?(.*)", prefixLength),
+			PathFmt:   `/\1`,
+		}
+	}
+	return &rules.ReqPathRewrite{
+		PathMatch: fmt.Sprintf("^.{%d}(.*)", prefixLength),
+		PathFmt:   escapeLogFormat(replacement) + `\1`,
+	}
+}
+
+// redirect returns the rule redirecting the request according the filter.
+// Without hostname nor port, the host header of the request is kept as is.
+func (m httpRouteMatch) redirect(filter store.HTTPRequestRedirectFilter, tlsTerminate bool) rules.RequestRedirect {
+	scheme := "http"
+	if tlsTerminate {
+		scheme = "https"
+	}
+	if filter.Scheme != nil {
+		scheme = *filter.Scheme
+	}
+	host := "%[req.hdr(host)]"
+	if filter.Hostname != nil || filter.Port != nil {
+		host = "%[req.hdr(host),field(1,:)]"
+		if filter.Hostname != nil {
+			host = *filter.Hostname
+		}
+		if port := filter.Port; port != nil && !(scheme == "http" && *port == 80 || scheme == "https" && *port == 443) {
+			host += ":" + strconv.Itoa(int(*port))
+		}
+	}
+	redirect := rules.RequestRedirect{
+		Host:         host,
+		RedirectCode: 302,
+		SSLRequest:   scheme == "https",
+	}
+	if filter.StatusCode != nil {
+		redirect.RedirectCode = int64(*filter.StatusCode)
+	}
+	if path := filter.Path; path != nil {
+		if path.Type == string(v1beta1.FullPathHTTPPathModifier) {
+			redirect.URI = escapeLogFormat(defaultPath(path.ReplaceFullPath)) + "%[capture.req.uri,regsub(^[^?]*,,)]"
+		} else {
+			prefixLength, replacement := m.prefixReplacement(path)
+			redirect.URI = fmt.Sprintf("%s%%[capture.req.uri,bytes(%d)]", escapeLogFormat(replacement), prefixLength)
+			if replacement == "" {
+				redirect.URI = fmt.Sprintf("%%[capture.req.uri,bytes(%d),regsub(^/?,/)]", prefixLength)
+			}
+		}
+	}
+	return redirect
+}
+
+// prefixReplacement returns the length of the prefix matched by the match and the replacement of this prefix, both without trailing '/'.
+func (m httpRouteMatch) prefixReplacement(path *store.HTTPPathModifier) (int, string) {
+	_, prefix := m.path()
+	replacement := ""
+	if path.ReplacePrefixMatch != nil {
+		replacement = *path.ReplacePrefixMatch
+	}
+	return len(strings.TrimSuffix(prefix, "/")), strings.TrimSuffix(replacement, "/")
+}
+
+func defaultPath(path *string) string {
+	if path == nil || *path == "" {
+		return "/"
+	}
+	return *path
+}
+
+// escapeLogFormat escapes the value so that it's not interpreted as a log-format expression.
+func escapeLogFormat(value string) string {
+	return strings.ReplaceAll(value, "%", "%%")
+}
+
+// quoteLogFormat quotes the value so that it can safely be used as a literal log-format string.
+func quoteLogFormat(value string) string {
+	return quoteACLValue(escapeLogFormat(value))
+}
@@ -0,0 +1,66 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gateway
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/rules"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+)
+
+func TestCheckHTTPRouteFilters(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  store.HTTPRouteFilter
+		wantErr string
+	}{
+		{
+			name:   "header modifier",
+			filter: store.HTTPRouteFilter{Type: "RequestHeaderModifier", RequestHeaderModifier: &store.HTTPHeaderFilter{}},
+		},
+		{
+			name:    "request mirror",
+			filter:  store.HTTPRouteFilter{Type: "RequestMirror"},
+			wantErr: "HAProxy cannot mirror requests",
+		},
+		{
+			name:    "extension reference",
+			filter:  store.HTTPRouteFilter{Type: "ExtensionRef"},
+			wantErr: "filter type 'ExtensionRef' is not supported",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkHTTPRouteFilters(store.HTTPRouteRule{Filters: []store.HTTPRouteFilter{tt.filter}})
+			if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("checkHTTPRouteFilters() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestURLRewriteHostname(t *testing.T) {
+	hostname := "backend.example.local"
+	m := httpRouteMatch{filters: []store.HTTPRouteFilter{{Type: "URLRewrite", URLRewrite: &store.HTTPURLRewriteFilter{Hostname: &hostname}}}}
+	filterRules := m.filterRules(false)
+	if len(filterRules) != 1 {
+		t.Fatalf("filterRules() = %v, want a single rule", filterRules)
+	}
+	if rule, ok := filterRules[0].(rules.ReqSetHost); !ok || rule.Host != hostname {
+		t.Errorf("filterRules() = %#v, want the host set to '%s'", filterRules[0], hostname)
+	}
+}
@@ -0,0 +1,519 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gateway
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/haproxytech/client-native/v6/models"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/instance"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/rules"
+	"github.com/haproxytech/kubernetes-ingress/pkg/service"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// HTTPRouteVar is the transaction variable holding the backend elected by the httproutes matches.
+const HTTPRouteVar = "txn.gw_backend"
+
+// HTTPRouteRandVar is the transaction variable holding the random number splitting the requests
+// between the backendRefs of the rules having several ones.
+const HTTPRouteRandVar = "txn.gw_rand"
+
+// httpRouteInvalidBackend is the value of HTTPRouteVar for the requests sent to invalid backendRefs,
+// they get a 500 response.
+const httpRouteInvalidBackend = "gw_invalid_backend"
+
+// tokenRegex matches the HTTP tokens without quotes, which would be parsed as such in sample fetch arguments.
+var tokenRegex = regexp.MustCompile("^[!#$%&*+.^_`|~0-9A-Za-z-]+$")
+
+// httpRouteMatch is a match of an httproute rule bound to one of the hostnames accepted by a listener.
+type httpRouteMatch struct {
+	route    *store.HTTPRoute
+	match    store.HTTPRouteMatch
+	hostname string
+	backends []httpRouteBackend
+	filters  []store.HTTPRouteFilter
+}
+
+// httpRouteBackend is the backend of the service of a backendRef of an httproute rule and its weight.
+// The name is httpRouteInvalidBackend when the backendRef is invalid.
+type httpRouteBackend struct {
+	name   string
+	weight int64
+}
+
+// manageHTTPRoutes creates backends from httproutes rules and attaches them to corresponding frontends according attachment rules.
+func (gm GatewayManagerImpl) manageHTTPRoutes() {
+	// Every listener (frontend) can have many httproutes attached, the matches of all of them
+	// are collected and then ordered by precedence before being turned into HAProxy rules.
+	matchesByFrontend := map[string][]httpRouteMatch{}
+	for _, ns := range gm.k8sStore.Namespaces {
+		if !ns.Relevant {
+			logger.Debugf("gwapi: skipping namespace '%s'", ns.Name)
+			continue
+		}
+		logger.Debugf("gwapi: namespace '%s' has %d httproutes", ns.Name, len(ns.HTTPRoutes))
+		for httproutename, httproute := range ns.HTTPRoutes {
+			if httproute == nil {
+				logger.Warningf("gwapi: nil httproute under name '%s'", httproutename)
+				continue
+			}
+			routeKey := getHTTPRouteKey(*httproute)
+			if httproute.Status == store.DELETED {
+				delete(ns.HTTPRoutes, httproute.Name)
+				delete(gm.listenersByRoute, routeKey)
+				instance.Reload("httproute '%s/%s' deleted", httproute.Namespace, httproute.Name)
+				continue
+			}
+			gm.statusManager.PrepareHTTPRouteStatusRecord(*httproute)
+
+			// Get the list of listeners (frontends) this httproute wants to be attached to.
+			listeners, errListeners := gm.getOurListenersFromRoute(K8S_HTTPROUTE_KIND, httproute.Namespace, httproute.Name, httproute.ParentRefs, httproute.Hostnames)
+			logger.Error(errListeners)
+			previousAssociatedListeners := gm.listenersByRoute[routeKey]
+			gm.listenersByRoute[routeKey] = listeners
+
+			instance.ReloadIf(((len(listeners) != 0 || len(listeners) == 0 && len(previousAssociatedListeners) != 0) &&
+				!utils.EqualSliceByIDFunc(listeners, previousAssociatedListeners, extractNameFromListener)),
+				"modification in listeners for httproute '%s/%s'", httproute.Namespace, httproute.Name)
+
+			if len(listeners) == 0 {
+				continue
+			}
+
+			for i, rule := range httproute.Rules {
+				// A rule with matches or filters that can't be configured is ignored.
+				errRule := checkHTTPRouteMatches(rule)
+				if errRule == nil {
+					errRule = checkHTTPRouteFilters(rule)
+				}
+				if errRule != nil {
+					msg := fmt.Sprintf("rule %d: %s", i, errRule)
+					gm.statusManager.SetRouteReasonUnsupportedValue(msg)
+					logger.Errorf("gwapi: httproute '%s/%s': %s", httproute.Namespace, httproute.Name, msg)
+					continue
+				}
+				backends := gm.addHTTPRouteBackends(*httproute, rule.BackendRefs, listeners)
+
+				matches := rule.Matches
+				if len(matches) == 0 {
+					matches = []store.HTTPRouteMatch{{Path: &store.HTTPPathMatch{Type: string(v1beta1.PathMatchPathPrefix), Value: "/"}}}
+				}
+				for _, listener := range listeners {
+					frontendName := getFrontendName(listener)
+					for _, hostname := range getListenerHostnames(listener, httproute.Hostnames) {
+						for _, match := range matches {
+							matchesByFrontend[frontendName] = append(matchesByFrontend[frontendName], httpRouteMatch{
+								route:    httproute,
+								match:    match,
+								hostname: hostname,
+								backends: backends,
+								filters:  rule.Filters,
+							})
+						}
+					}
+				}
+			}
+			for _, listener := range listeners {
+				// the counter of attached routes for listener status is incremented.
+				gm.statusManager.IncrementRouteForListener(listener)
+			}
+		}
+	}
+
+	logger.Error(gm.addHTTPRouteMatchesToFrontends(matchesByFrontend))
+}
+
+// addHTTPRouteBackends creates the backends of the services of the backendRefs of an httproute rule.
+// They are the same backends as the ones of the ingresses, configured with the annotations of their service
+// and of the controller configmap, or with the Backend CR of the gatewayclass of the first listener the route
+// is attached to when the service has no cr-backend annotation.
+// BackendRefs with a weight of 0 are skipped, invalid ones are kept with their weight so that the requests
+// they would have received get a 500 response.
+func (gm GatewayManagerImpl) addHTTPRouteBackends(httproute store.HTTPRoute, backendRefs []store.BackendRef, listeners []store.Listener) []httpRouteBackend {
+	annList := []map[string]string{gm.k8sStore.ConfigMaps.Main.Annotations}
+	if crBackend := gm.getListenerBackendCR(listeners[0]); crBackend != "" {
+		annList = []map[string]string{{"cr-backend": crBackend}, gm.k8sStore.ConfigMaps.Main.Annotations}
+	}
+	backends := []httpRouteBackend{}
+	for id, backendRef := range backendRefs {
+		backend := httpRouteBackend{name: httpRouteInvalidBackend, weight: 1}
+		if backendRef.Weight != nil {
+			backend.weight = int64(*backendRef.Weight)
+		}
+		if backend.weight <= 0 {
+			continue
+		}
+		if backendName, err := gm.addHTTPRouteBackend(httproute, id, backendRef, annList); err != nil {
+			logger.Errorf("gwapi: httproute '%s/%s': backendRef number '%d': %s", httproute.Namespace, httproute.Name, id, err)
+		} else if backendName != "" {
+			backend.name = backendName
+		}
+		backends = append(backends, backend)
+	}
+	return backends
+}
+
+// addHTTPRouteBackend creates the backend of the service of the backendRef and its servers, once per sync,
+// and returns its name, empty if the backendRef is not valid.
+func (gm GatewayManagerImpl) addHTTPRouteBackend(httproute store.HTTPRoute, id int, backendRef store.BackendRef, annList []map[string]string) (string, error) {
+	svcResource, _, ok := gm.getBackendRefService(K8S_HTTPROUTE_KIND, httproute.Namespace, httproute.Name, id, backendRef)
+	if !ok {
+		return "", nil
+	}
+	path := &store.IngressPath{
+		SvcNamespace: svcResource.Namespace,
+		SvcName:      svcResource.Name,
+		SvcPortInt:   int64(*backendRef.Port),
+	}
+	svc, err := service.New(gm.k8sStore, path, gm.certificates, false, nil, annList...)
+	if err != nil {
+		return "", err
+	}
+	if err = svc.HandleBackend(gm.k8sStore, gm.haproxyClient, gm.annotations); err != nil {
+		return "", err
+	}
+	backendName, err := svc.GetBackendName()
+	if err != nil {
+		return "", err
+	}
+	if _, ok := gm.k8sStore.BackendsProcessed[backendName]; !ok {
+		svc.HandleHAProxySrvs(gm.k8sStore, gm.haproxyClient)
+		gm.k8sStore.BackendsProcessed[backendName] = struct{}{}
+	}
+	return backendName, nil
+}
+
+// addHTTPRouteMatchesToFrontends orders the matches of every http frontend by precedence
+// and creates the rules electing the backend of the first matching one.
+// Requests which don't match any httproute get a 404 response.
+func (gm GatewayManagerImpl) addHTTPRouteMatchesToFrontends(matchesByFrontend map[string][]httpRouteMatch) error {
+	var errs utils.Errors
+	for frontendName := range gm.rulesByFrontend {
+		if _, ok := gm.frontends[frontendName]; !ok {
+			delete(gm.rulesByFrontend, frontendName)
+		}
+	}
+	for frontendName := range gm.frontends {
+		frontend, err := gm.haproxyClient.FrontendGet(frontendName)
+		if err != nil {
+			errs.Add(err)
+			continue
+		}
+		if frontend.Mode != "http" {
+			continue
+		}
+		routeMatches := matchesByFrontend[frontendName]
+		sort.SliceStable(routeMatches, func(i, j int) bool {
+			return routeMatches[i].less(routeMatches[j])
+		})
+
+		tlsTerminate := gm.isTLSTerminating(frontendName)
+		frontendRules := make([]string, len(routeMatches))
+		filterRules := make([][]rules.Rule, len(routeMatches))
+		splitRequests := false
+		for i, routeMatch := range routeMatches {
+			frontendRules[i] = routeMatch.condition() + " -> " + routeMatch.backendsKey()
+			filterRules[i] = routeMatch.filterRules(tlsTerminate)
+			// Requests sent to invalid backendRefs get a 500 response once the filters are applied.
+			if routeMatch.hasInvalidBackend() {
+				filterRules[i] = append(filterRules[i], rules.ReqReturn{
+					StatusCode: 500,
+					CondTest:   fmt.Sprintf("{ var(%s) -m str %s }", HTTPRouteVar, httpRouteInvalidBackend),
+				})
+			}
+			splitRequests = splitRequests || len(routeMatch.backends) > 1
+			for _, rule := range filterRules[i] {
+				frontendRules[i] += " " + string(rules.GetID(rule))
+			}
+		}
+		instance.ReloadIf(!utils.EqualSliceComparable(frontendRules, gm.rulesByFrontend[frontendName]),
+			"modification in httproutes rules of frontend '%s'", frontendName)
+		gm.rulesByFrontend[frontendName] = frontendRules
+
+		// Rules are inserted on top of the list so they are created in reverse order.
+		// Filters are applied once the match is elected, the index of the match tells which ones.
+		for i := len(routeMatches) - 1; i >= 0; i-- {
+			matchACL := fmt.Sprintf("{ var(%s) -m int %d }", HTTPRouteMatchVar, i)
+			for j := len(filterRules[i]) - 1; j >= 0; j-- {
+				errs.Add(filterRules[i][j].Create(gm.haproxyClient, &frontend, matchACL))
+			}
+		}
+		errs.Add(gm.haproxyClient.FrontendHTTPRequestRuleCreate(0, frontendName, models.HTTPRequestRule{
+			Type:       "deny",
+			DenyStatus: utils.PtrInt64(404),
+			Cond:       "unless",
+			CondTest:   fmt.Sprintf("{ var(%s) -m found }", HTTPRouteVar),
+		}, ""))
+		for i := len(routeMatches) - 1; i >= 0; i-- {
+			backendRules := routeMatches[i].backendRules()
+			for j := len(backendRules) - 1; j >= 0; j-- {
+				errs.Add(gm.haproxyClient.FrontendHTTPRequestRuleCreate(0, frontendName, backendRules[j], ""))
+			}
+			if len(filterRules[i]) == 0 {
+				continue
+			}
+			errs.Add(gm.haproxyClient.FrontendHTTPRequestRuleCreate(0, frontendName, models.HTTPRequestRule{
+				Type:     "set-var",
+				VarName:  strings.TrimPrefix(HTTPRouteMatchVar, "txn."),
+				VarScope: "txn",
+				VarExpr:  fmt.Sprintf("int(%d)", i),
+				Cond:     "if",
+				CondTest: fmt.Sprintf("%s !{ var(%s) -m found }", routeMatches[i].condition(), HTTPRouteVar),
+			}, ""))
+		}
+		if splitRequests {
+			errs.Add(gm.haproxyClient.FrontendHTTPRequestRuleCreate(0, frontendName, models.HTTPRequestRule{
+				Type:     "set-var",
+				VarName:  strings.TrimPrefix(HTTPRouteRandVar, "txn."),
+				VarScope: "txn",
+				VarExpr:  "rand",
+			}, ""))
+		}
+		errs.Add(gm.haproxyClient.BackendSwitchingRuleCreate(0, frontendName, models.BackendSwitchingRule{
+			Name:     fmt.Sprintf("%%[var(%s)]", HTTPRouteVar),
+			Cond:     "if",
+			CondTest: fmt.Sprintf("{ var(%s) -m found }", HTTPRouteVar),
+		}))
+	}
+	return errs.Result()
+}
+
+// backendRules returns the rules electing the backend of the match. When the match has several backends,
+// the requests are split between them according their weights with the random number of HTTPRouteRandVar.
+// A match without backend gets the invalid backend, its requests get a 500 response.
+func (m httpRouteMatch) backendRules() []models.HTTPRequestRule {
+	backends := m.backends
+	if len(backends) == 0 {
+		backends = []httpRouteBackend{{name: httpRouteInvalidBackend, weight: 1}}
+	}
+	var total int64
+	for _, backend := range backends {
+		total += backend.weight
+	}
+	condition := fmt.Sprintf("%s !{ var(%s) -m found }", m.condition(), HTTPRouteVar)
+	backendRules := make([]models.HTTPRequestRule, 0, len(backends))
+	var threshold int64
+	for i, backend := range backends {
+		rule := models.HTTPRequestRule{
+			Type:     "set-var",
+			VarName:  strings.TrimPrefix(HTTPRouteVar, "txn."),
+			VarScope: "txn",
+			VarExpr:  fmt.Sprintf("str(%s)", backend.name),
+			Cond:     "if",
+			CondTest: condition,
+		}
+		threshold += backend.weight
+		if i < len(backends)-1 {
+			rule.CondTest += fmt.Sprintf(" { var(%s),mod(%d) -m int lt %d }", HTTPRouteRandVar, total, threshold)
+		}
+		backendRules = append(backendRules, rule)
+	}
+	return backendRules
+}
+
+// hasInvalidBackend checks if some requests of the match are sent to the invalid backend.
+func (m httpRouteMatch) hasInvalidBackend() bool {
+	if len(m.backends) == 0 {
+		return true
+	}
+	for _, backend := range m.backends {
+		if backend.name == httpRouteInvalidBackend {
+			return true
+		}
+	}
+	return false
+}
+
+// backendsKey provides a representation of the backends of the match and their weights to detect their modifications.
+func (m httpRouteMatch) backendsKey() string {
+	keys := make([]string, len(m.backends))
+	for i, backend := range m.backends {
+		keys[i] = fmt.Sprintf("%s/%d", backend.name, backend.weight)
+	}
+	return strings.Join(keys, ",")
+}
+
+// checkHTTPRouteMatches returns an error if a header or query parameter name of the matches of the httproute rule
+// is not a token, such names can't be used as sample fetch arguments.
+func checkHTTPRouteMatches(rule store.HTTPRouteRule) error {
+	for _, match := range rule.Matches {
+		for _, header := range match.Headers {
+			if !tokenRegex.MatchString(header.Name) {
+				return fmt.Errorf("invalid header name '%s'", header.Name)
+			}
+		}
+		for _, queryParam := range match.QueryParams {
+			if !tokenRegex.MatchString(queryParam.Name) {
+				return fmt.Errorf("invalid query parameter name '%s'", queryParam.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// condition returns the HAProxy condition corresponding to the match.
+func (m httpRouteMatch) condition() string {
+	conditions := []string{}
+	switch {
+	case m.hostname == "":
+	case m.hostname[0] == '*':
+		conditions = append(conditions, fmt.Sprintf("{ req.hdr(host),field(1,:),lower -m end %s }", m.hostname[1:]))
+	default:
+		conditions = append(conditions, fmt.Sprintf("{ req.hdr(host),field(1,:),lower -m str %s }", m.hostname))
+	}
+	if m.match.Path != nil {
+		switch m.match.Path.Type {
+		case string(v1beta1.PathMatchExact):
+			conditions = append(conditions, fmt.Sprintf("{ path %s }", quoteACLValue(m.match.Path.Value)))
+		case string(v1beta1.PathMatchRegularExpression):
+			conditions = append(conditions, fmt.Sprintf("{ path -m reg %s }", quoteACLValue(m.match.Path.Value)))
+		default:
+			path := strings.TrimSuffix(m.match.Path.Value, "/")
+			if path == "" {
+				conditions = append(conditions, "{ path -m beg / }")
+			} else {
+				conditions = append(conditions, fmt.Sprintf("{ path -m reg %s }", quoteACLValue("^"+regexp.QuoteMeta(path)+"($|/)")))
+			}
+		}
+	}
+	if m.match.Method != nil {
+		conditions = append(conditions, fmt.Sprintf("{ method %s }", *m.match.Method))
+	}
+	for _, header := range m.match.Headers {
+		conditions = append(conditions, fmt.Sprintf("{ req.hdr(%s) %s }", header.Name, matchACLValue(header.Type, header.Value)))
+	}
+	for _, queryParam := range m.match.QueryParams {
+		conditions = append(conditions, fmt.Sprintf("{ url_param(%s) %s }", queryParam.Name, matchACLValue(queryParam.Type, queryParam.Value)))
+	}
+	return strings.Join(conditions, " ")
+}
+
+// less orders the matches according precedence rules of the Gateway API:
+// hostname specificity, exact path, regular expression path, longest prefix path,
+// method, number of headers, number of query parameters, oldest route, route name.
+func (m httpRouteMatch) less(other httpRouteMatch) bool {
+	if rank, otherRank := hostnameRank(m.hostname), hostnameRank(other.hostname); rank != otherRank {
+		return rank < otherRank
+	}
+	if len(m.hostname) != len(other.hostname) {
+		return len(m.hostname) > len(other.hostname)
+	}
+	pathType, pathValue := m.path()
+	otherPathType, otherPathValue := other.path()
+	if rank, otherRank := pathTypeRank(pathType), pathTypeRank(otherPathType); rank != otherRank {
+		return rank < otherRank
+	}
+	if len(pathValue) != len(otherPathValue) {
+		return len(pathValue) > len(otherPathValue)
+	}
+	if (m.match.Method != nil) != (other.match.Method != nil) {
+		return m.match.Method != nil
+	}
+	if len(m.match.Headers) != len(other.match.Headers) {
+		return len(m.match.Headers) > len(other.match.Headers)
+	}
+	if len(m.match.QueryParams) != len(other.match.QueryParams) {
+		return len(m.match.QueryParams) > len(other.match.QueryParams)
+	}
+	if !m.route.CreationTime.Equal(other.route.CreationTime) {
+		return m.route.CreationTime.Before(other.route.CreationTime)
+	}
+	return m.route.Namespace+"/"+m.route.Name < other.route.Namespace+"/"+other.route.Name
+}
+
+// path returns the path match type and value, a missing path match being a prefix match on '/'.
+func (m httpRouteMatch) path() (string, string) {
+	if m.match.Path == nil {
+		return string(v1beta1.PathMatchPathPrefix), "/"
+	}
+	return m.match.Path.Type, m.match.Path.Value
+}
+
+func hostnameRank(hostname string) int {
+	switch {
+	case hostname == "":
+		return 2
+	case hostname[0] == '*':
+		return 1
+	}
+	return 0
+}
+
+func pathTypeRank(pathType string) int {
+	switch pathType {
+	case string(v1beta1.PathMatchExact):
+		return 0
+	case string(v1beta1.PathMatchRegularExpression):
+		return 1
+	}
+	return 2
+}
+
+// matchACLValue returns the ACL matching method and pattern for an exact or regular expression match.
+func matchACLValue(matchType, value string) string {
+	if matchType == string(v1beta1.HeaderMatchRegularExpression) {
+		return "-m reg " + quoteACLValue(value)
+	}
+	return "-m str " + quoteACLValue(value)
+}
+
+// quoteACLValue quotes the value so that it can safely be used as an ACL pattern.
+func quoteACLValue(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+// getListenerHostnames returns the hostnames a route with the provided hostnames can be served for on the listener.
+// An empty hostname means any hostname, no hostname at all means that route and listener hostnames don't intersect.
+func getListenerHostnames(listener store.Listener, routeHostnames []string) []string {
+	listenerHostname := utils.PointerDefaultValueIfNil(listener.Hostname)
+	if len(routeHostnames) == 0 {
+		return []string{listenerHostname}
+	}
+	if listenerHostname == "" {
+		return routeHostnames
+	}
+	hostnames := []string{}
+	for _, routeHostname := range routeHostnames {
+		if hostname, ok := intersectHostnames(listenerHostname, routeHostname); ok {
+			hostnames = append(hostnames, hostname)
+		}
+	}
+	return hostnames
+}
+
+// intersectHostnames returns the most specific of both hostnames if they can match the same requests.
+func intersectHostnames(hostnameA, hostnameB string) (string, bool) {
+	wildcardA, wildcardB := strings.HasPrefix(hostnameA, "*"), strings.HasPrefix(hostnameB, "*")
+	switch {
+	case hostnameA == hostnameB:
+		return hostnameA, true
+	case wildcardA && strings.HasSuffix(hostnameB, hostnameA[1:]):
+		return hostnameB, true
+	case wildcardB && strings.HasSuffix(hostnameA, hostnameB[1:]):
+		return hostnameA, true
+	}
+	return "", false
+}
+
+// getHTTPRouteKey provides the key of the httproute in the listeners by route bookkeeping.
+func getHTTPRouteKey(httproute store.HTTPRoute) string {
+	return K8S_HTTPROUTE_KIND + "/" + httproute.Namespace + "/" + httproute.Name
+}
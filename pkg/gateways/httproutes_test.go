@@ -0,0 +1,129 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gateway
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+)
+
+func TestCheckHTTPRouteMatches(t *testing.T) {
+	tests := []struct {
+		name    string
+		match   store.HTTPRouteMatch
+		wantErr bool
+	}{
+		{
+			name: "valid names",
+			match: store.HTTPRouteMatch{
+				Headers:     []store.HTTPHeaderMatch{{Type: "Exact", Name: "X-Env", Value: "canary"}},
+				QueryParams: []store.HTTPQueryParamMatch{{Type: "Exact", Name: "debug_mode", Value: "true"}},
+			},
+		},
+		{
+			name: "header name closing the sample fetch",
+			match: store.HTTPRouteMatch{
+				Headers: []store.HTTPHeaderMatch{{Type: "Exact", Name: "X-Env) || { src 0.0.0.0/0 } #", Value: "x"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "query parameter name with a comma",
+			match: store.HTTPRouteMatch{
+				QueryParams: []store.HTTPQueryParamMatch{{Type: "Exact", Name: "a,b", Value: "x"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "query parameter name with a quote",
+			match: store.HTTPRouteMatch{
+				QueryParams: []store.HTTPQueryParamMatch{{Type: "Exact", Name: "a'b", Value: "x"}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkHTTPRouteMatches(store.HTTPRouteRule{Matches: []store.HTTPRouteMatch{tt.match}})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkHTTPRouteMatches() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHTTPRouteMatchBackendRules(t *testing.T) {
+	const condition = `{ path -m beg / } !{ var(txn.gw_backend) -m found }`
+	tests := []struct {
+		name     string
+		backends []httpRouteBackend
+		want     []string
+		invalid  bool
+	}{
+		{
+			name:     "single backend",
+			backends: []httpRouteBackend{{name: "ns_svc_app_http", weight: 1}},
+			want:     []string{"str(ns_svc_app_http) if " + condition},
+		},
+		{
+			name: "weighted backends",
+			backends: []httpRouteBackend{
+				{name: "ns_svc_app_http", weight: 3},
+				{name: "ns_svc_app-v2_http", weight: 1},
+			},
+			want: []string{
+				"str(ns_svc_app_http) if " + condition + " { var(txn.gw_rand),mod(4) -m int lt 3 }",
+				"str(ns_svc_app-v2_http) if " + condition,
+			},
+		},
+		{
+			name: "invalid backend",
+			backends: []httpRouteBackend{
+				{name: "ns_svc_app_http", weight: 1},
+				{name: httpRouteInvalidBackend, weight: 1},
+			},
+			want: []string{
+				"str(ns_svc_app_http) if " + condition + " { var(txn.gw_rand),mod(2) -m int lt 1 }",
+				"str(" + httpRouteInvalidBackend + ") if " + condition,
+			},
+			invalid: true,
+		},
+		{
+			name:    "no backend",
+			want:    []string{"str(" + httpRouteInvalidBackend + ") if " + condition},
+			invalid: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := httpRouteMatch{
+				match:    store.HTTPRouteMatch{Path: &store.HTTPPathMatch{Type: "PathPrefix", Value: "/"}},
+				backends: tt.backends,
+			}
+			got := []string{}
+			for _, rule := range m.backendRules() {
+				got = append(got, rule.VarExpr+" "+rule.Cond+" "+rule.CondTest)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("backendRules() = %v, want %v", got, tt.want)
+			}
+			if m.hasInvalidBackend() != tt.invalid {
+				t.Errorf("hasInvalidBackend() = %v, want %v", m.hasInvalidBackend(), tt.invalid)
+			}
+		})
+	}
+}
@@ -74,40 +74,57 @@ func NewStatusManager(k8sRestClient client.Client, gatewayControllerName string)
 		gatewayControllerName:                gatewayControllerName,
 		numRoutesByListenerByGateway:         map[string]map[string]int32{},
 		previousNumRoutesByListenerByGateway: map[string]map[string]int32{},
+		addressesByGateway:                   map[string][]store.GatewayAddress{},
 	}
 }
 
 type RouteStatusManager interface {
 	SetRouteReasonInvalidKind(string)
+	SetRouteReasonUnsupportedValue(string)
 	SetRouteReasonBackendNotFound(string)
 	SetRouteReasonRefNotPermitted(string)
 	SetRouteReasonNotAllowedByListeners(string, store.ParentRef)
+	SetRouteReasonNoMatchingListenerHostname(string, store.ParentRef)
 }
 
 type StatusManager interface {
 	ProcessStatuses()
 	PrepareGatewayStatus(store.Gateway)
 	PrepareTCPRouteStatusRecord(store.TCPRoute)
+	PrepareHTTPRouteStatusRecord(store.HTTPRoute)
+	PrepareTLSRouteStatusRecord(store.TLSRoute)
 	PrepareListenerStatus(store.Listener)
 	SetListenerReasonUnsupportedProtocol(string)
 	SetListenerReasonInvalidRouteKinds(string, []store.RouteGroupKind)
+	SetListenerReasonInvalidCertificateRef(string)
+	SetListenerReasonRefNotPermitted(string)
 	RouteStatusManager
 	SetGatewayClassConditionStatusAccepted(store.GatewayClass)
+	SetGatewayClassReasonInvalidParameters(store.GatewayClass, string)
 	AddManagedParentRef(parentRef store.ParentRef)
 	IncrementRouteForListener(store.Listener)
+	SetGatewayAddresses([]store.GatewayAddress)
+	SetGatewayReasonAddressNotAssigned(string)
 }
 
 type StatusManagerImpl struct {
 	k8sRestClient                        client.Client
 	gateway                              *gatewayStatusRecord
 	listener                             *listenerStatusRecord
-	tcproute                             *routeStatusRecord
+	route                                *routeStatusRecord
 	numRoutesByListenerByGateway         map[string]map[string]int32
 	previousNumRoutesByListenerByGateway map[string]map[string]int32
+	addressesByGateway                   map[string][]store.GatewayAddress
 	gatewayControllerName                string
-	gatewayclasses                       []store.GatewayClass
+	gatewayclasses                       []gatewayclassStatusRecord
 	gateways                             []gatewayStatusRecord
-	tcproutes                            []routeStatusRecord
+	routes                               []routeStatusRecord
+}
+
+// gatewayclassStatusRecord holds the gatewayclass and the reason why its parameters are invalid if any.
+type gatewayclassStatusRecord struct {
+	invalidParameters string
+	gatewayclass      store.GatewayClass
 }
 
 // status records are created for two purposes:
@@ -118,6 +135,7 @@ type routeStatusRecord struct {
 	generalConditions      map[string]string
 	name                   string
 	namespace              string
+	kind                   string
 	status                 store.Status
 	generation             int64
 }
@@ -132,8 +150,11 @@ type gatewayStatusRecord struct {
 	namespace                string
 	status                   store.Status
 	listenersStatusesRecords []listenerStatusRecord
+	addresses                []store.GatewayAddress
+	addressNotAssigned       string
 	generation               int64
 	listenerWithError        bool
+	addressesChanged         bool
 }
 
 type listenerStatusRecord struct {
@@ -159,6 +180,9 @@ func (gwStatusRecord *gatewayStatusRecord) copy() gatewayStatusRecord {
 		generation:               gwStatusRecord.generation,
 		listenerWithError:        gwStatusRecord.listenerWithError,
 		listenersStatusesRecords: listenersStatusesRecords,
+		addresses:                append([]store.GatewayAddress(nil), gwStatusRecord.addresses...),
+		addressNotAssigned:       gwStatusRecord.addressNotAssigned,
+		addressesChanged:         gwStatusRecord.addressesChanged,
 		status:                   gwStatusRecord.status,
 	}
 }
@@ -176,6 +200,7 @@ func (rteStatusRecord *routeStatusRecord) copy() routeStatusRecord {
 	return routeStatusRecord{
 		name:                   rteStatusRecord.name,
 		namespace:              rteStatusRecord.namespace,
+		kind:                   rteStatusRecord.kind,
 		generalConditions:      utils.CopyMap(rteStatusRecord.generalConditions),
 		generation:             rteStatusRecord.generation,
 		parentsStatusesRecords: parentsStatusesRecords,
@@ -183,11 +208,11 @@ func (rteStatusRecord *routeStatusRecord) copy() routeStatusRecord {
 	}
 }
 
-// pushTCPRoute pushes the current tcproute whose status is set by gatewaycontroller to the list of previous ones
-func (statusMgr *StatusManagerImpl) pushTCPRoute() {
-	if statusMgr.tcproute != nil {
-		statusMgr.tcproutes = append(statusMgr.tcproutes, *statusMgr.tcproute)
-		statusMgr.tcproute = nil
+// pushRoute pushes the current route whose status is set by gatewaycontroller to the list of previous ones
+func (statusMgr *StatusManagerImpl) pushRoute() {
+	if statusMgr.route != nil {
+		statusMgr.routes = append(statusMgr.routes, *statusMgr.route)
+		statusMgr.route = nil
 	}
 }
 
@@ -207,18 +232,18 @@ func (statusMgr *StatusManagerImpl) pushListener() {
 	}
 }
 
-// copyTCPRoutesStatusRecords returns a copy of all the routes statuses.
-func (statusMgr *StatusManagerImpl) copyTCPRoutesStatusRecords() []routeStatusRecord {
-	copies := make([]routeStatusRecord, len(statusMgr.tcproutes))
-	for i, data := range statusMgr.tcproutes {
+// copyRoutesStatusRecords returns a copy of all the routes statuses.
+func (statusMgr *StatusManagerImpl) copyRoutesStatusRecords() []routeStatusRecord {
+	copies := make([]routeStatusRecord, len(statusMgr.routes))
+	for i, data := range statusMgr.routes {
 		copies[i] = data.copy()
 	}
 	return copies
 }
 
-// copyGatewayclasses returns a copy of all the gatewayclasses. Note we don't need a specific gatewayclass status record because of simplicity.
-func (statusMgr *StatusManagerImpl) copyGatewayclasses() []store.GatewayClass {
-	copies := make([]store.GatewayClass, len(statusMgr.gatewayclasses))
+// copyGatewayclasses returns a copy of all the gatewayclasses statuses.
+func (statusMgr *StatusManagerImpl) copyGatewayclasses() []gatewayclassStatusRecord {
+	copies := make([]gatewayclassStatusRecord, len(statusMgr.gatewayclasses))
 	copy(copies, statusMgr.gatewayclasses)
 	return copies
 }
@@ -246,6 +271,20 @@ func (statusMgr *StatusManagerImpl) PrepareGatewayStatus(gateway store.Gateway)
 	}
 }
 
+// SetGatewayAddresses sets the addresses of the current gateway pushed by PrepareGatewayStatus.
+// A change of addresses, for example of the publish service ones, requires an update of the gateway status.
+func (statusMgr *StatusManagerImpl) SetGatewayAddresses(addresses []store.GatewayAddress) {
+	key := statusMgr.gateway.namespace + "/" + statusMgr.gateway.name
+	statusMgr.gateway.addresses = addresses
+	statusMgr.gateway.addressesChanged = !store.GatewayAddresses(addresses).Equal(statusMgr.addressesByGateway[key])
+	statusMgr.addressesByGateway[key] = addresses
+}
+
+// SetGatewayReasonAddressNotAssigned sets the msg and the reason GatewayReasonAddressNotAssigned for the current gateway pushed by PrepareGatewayStatus.
+func (statusMgr *StatusManagerImpl) SetGatewayReasonAddressNotAssigned(msg string) {
+	statusMgr.gateway.addressNotAssigned = msg
+}
+
 // PrepareListenerStatus sets the listener status record for a listener.
 // Every upcoming status information about a listener provided by the gateway controller will be set into this record.
 func (statusMgr *StatusManagerImpl) PrepareListenerStatus(listener store.Listener) {
@@ -265,11 +304,12 @@ func (statusMgr *StatusManagerImpl) PrepareListenerStatus(listener store.Listene
 // PrepareTCPRouteStatusRecord sets the tcproute status record for a tcproute.
 // Every upcoming status information about a tcproute provided by the gateway controller will be set into this record.
 func (statusMgr *StatusManagerImpl) PrepareTCPRouteStatusRecord(tcproute store.TCPRoute) {
-	statusMgr.pushTCPRoute()
+	statusMgr.pushRoute()
 
-	statusMgr.tcproute = &routeStatusRecord{
+	statusMgr.route = &routeStatusRecord{
 		name:                   tcproute.Name,
 		namespace:              tcproute.Namespace,
+		kind:                   K8S_TCPROUTE_KIND,
 		generation:             tcproute.Generation,
 		parentsStatusesRecords: map[string]parentrefStatusRecord{},
 		generalConditions:      map[string]string{},
@@ -277,21 +317,53 @@ func (statusMgr *StatusManagerImpl) PrepareTCPRouteStatusRecord(tcproute store.T
 	}
 }
 
+// PrepareTLSRouteStatusRecord sets the tlsroute status record for a tlsroute.
+// Every upcoming status information about a tlsroute provided by the gateway controller will be set into this record.
+func (statusMgr *StatusManagerImpl) PrepareTLSRouteStatusRecord(tlsroute store.TLSRoute) {
+	statusMgr.pushRoute()
+
+	statusMgr.route = &routeStatusRecord{
+		name:                   tlsroute.Name,
+		namespace:              tlsroute.Namespace,
+		kind:                   K8S_TLSROUTE_KIND,
+		generation:             tlsroute.Generation,
+		parentsStatusesRecords: map[string]parentrefStatusRecord{},
+		generalConditions:      map[string]string{},
+		status:                 tlsroute.Status,
+	}
+}
+
+// PrepareHTTPRouteStatusRecord sets the httproute status record for a httproute.
+// Every upcoming status information about a httproute provided by the gateway controller will be set into this record.
+func (statusMgr *StatusManagerImpl) PrepareHTTPRouteStatusRecord(httproute store.HTTPRoute) {
+	statusMgr.pushRoute()
+
+	statusMgr.route = &routeStatusRecord{
+		name:                   httproute.Name,
+		namespace:              httproute.Namespace,
+		kind:                   K8S_HTTPROUTE_KIND,
+		generation:             httproute.Generation,
+		parentsStatusesRecords: map[string]parentrefStatusRecord{},
+		generalConditions:      map[string]string{},
+		status:                 httproute.Status,
+	}
+}
+
 // ProcessStatuses goes over all status records to update their counterparts in k8s with the corresponding resource.
 func (statusMgr *StatusManagerImpl) ProcessStatuses() {
 	statusMgr.pushListener()
 	statusMgr.pushGateway()
-	statusMgr.pushTCPRoute()
+	statusMgr.pushRoute()
 	copyGatewaysStatusRecords := statusMgr.copyGatewaysStatusRecords()
-	copyTCPRouteStatusRecords := statusMgr.copyTCPRoutesStatusRecords()
+	copyRouteStatusRecords := statusMgr.copyRoutesStatusRecords()
 	copyGatewayclasses := statusMgr.copyGatewayclasses()
 	statusMgr.gatewayclasses = nil
 	statusMgr.gateways = nil
-	statusMgr.tcproutes = nil
+	statusMgr.routes = nil
 	// we update asynchonously all statuses.
 	go statusMgr.UpdateStatusGatewayclasses(copyGatewayclasses)
 	go statusMgr.UpdateStatusGateways(copyGatewaysStatusRecords, utils.CopyMapOfMap(statusMgr.numRoutesByListenerByGateway), utils.CopyMapOfMap(statusMgr.previousNumRoutesByListenerByGateway))
-	go statusMgr.UpdateStatusTCPRoutes(copyTCPRouteStatusRecords)
+	go statusMgr.UpdateStatusRoutes(copyRouteStatusRecords)
 
 	statusMgr.previousNumRoutesByListenerByGateway = statusMgr.numRoutesByListenerByGateway
 	statusMgr.numRoutesByListenerByGateway = map[string]map[string]int32{}
@@ -310,39 +382,71 @@ func (statusMgr *StatusManagerImpl) SetListenerReasonInvalidRouteKinds(msg strin
 	statusMgr.gateway.listenerWithError = true
 }
 
-// SetRouteReasonBackendNotFound sets the msg and the reason RouteReasonBackendNotFound for the current tcp route pushed by PrepareTCPRouteStatus.
+// SetListenerReasonInvalidCertificateRef sets the msg and the reason ListenerReasonInvalidCertificateRef for the current listener pushed by PrepareListenerStatus.
+func (statusMgr *StatusManagerImpl) SetListenerReasonInvalidCertificateRef(msg string) {
+	statusMgr.listener.reasons[ListenerReasonInvalidCertificateRef] = msg
+	statusMgr.gateway.listenerWithError = true
+}
+
+// SetListenerReasonRefNotPermitted sets the msg and the reason ListenerReasonRefNotPermitted for the current listener pushed by PrepareListenerStatus.
+func (statusMgr *StatusManagerImpl) SetListenerReasonRefNotPermitted(msg string) {
+	statusMgr.listener.reasons[ListenerReasonRefNotPermitted] = msg
+	statusMgr.gateway.listenerWithError = true
+}
+
+// SetRouteReasonBackendNotFound sets the msg and the reason RouteReasonBackendNotFound for the current route pushed by PrepareTCPRouteStatus, PrepareHTTPRouteStatus or PrepareTLSRouteStatus.
 func (statusMgr *StatusManagerImpl) SetRouteReasonBackendNotFound(msg string) {
-	statusMgr.tcproute.generalConditions[RouteReasonBackendNotFound] = msg
+	statusMgr.route.generalConditions[RouteReasonBackendNotFound] = msg
 }
 
-// SetRouteReasonBackendNotFound sets the msg and the reason RouteReasonRefNotPermitted for the current tcp route pushed by PrepareTCPRouteStatus.
+// SetRouteReasonBackendNotFound sets the msg and the reason RouteReasonRefNotPermitted for the current route pushed by PrepareTCPRouteStatus, PrepareHTTPRouteStatus or PrepareTLSRouteStatus.
 func (statusMgr *StatusManagerImpl) SetRouteReasonRefNotPermitted(msg string) {
-	statusMgr.tcproute.generalConditions[RouteReasonRefNotPermitted] = msg
+	statusMgr.route.generalConditions[RouteReasonRefNotPermitted] = msg
 }
 
-// SetRouteReasonBackendNotFound sets the msg and the reason RouteReasonNotAllowedByListeners for the current tcp route pushed by PrepareTCPRouteStatus.
+// SetRouteReasonBackendNotFound sets the msg and the reason RouteReasonNotAllowedByListeners for the current route pushed by PrepareTCPRouteStatus, PrepareHTTPRouteStatus or PrepareTLSRouteStatus.
 func (statusMgr *StatusManagerImpl) SetRouteReasonNotAllowedByListeners(msg string, parentRef store.ParentRef) {
-	parentStatusRecord := statusMgr.tcproute.parentsStatusesRecords[*parentRef.Namespace+"/"+parentRef.Name]
+	parentStatusRecord := statusMgr.route.parentsStatusesRecords[*parentRef.Namespace+"/"+parentRef.Name]
 	if parentStatusRecord.reasons == nil {
 		parentStatusRecord.reasons = map[string]string{}
 	}
 	parentStatusRecord.reasons[RouteReasonNotAllowedByListeners] += msg + "\n"
-	statusMgr.tcproute.parentsStatusesRecords[*parentRef.Namespace+"/"+parentRef.Name] = parentStatusRecord
+	statusMgr.route.parentsStatusesRecords[*parentRef.Namespace+"/"+parentRef.Name] = parentStatusRecord
+}
+
+// SetRouteReasonNoMatchingListenerHostname sets the msg and the reason RouteReasonNoMatchingListenerHostname for the current route pushed by PrepareHTTPRouteStatus or PrepareTLSRouteStatus.
+func (statusMgr *StatusManagerImpl) SetRouteReasonNoMatchingListenerHostname(msg string, parentRef store.ParentRef) {
+	parentStatusRecord := statusMgr.route.parentsStatusesRecords[*parentRef.Namespace+"/"+parentRef.Name]
+	if parentStatusRecord.reasons == nil {
+		parentStatusRecord.reasons = map[string]string{}
+	}
+	parentStatusRecord.reasons[RouteReasonNoMatchingListenerHostname] += msg + "\n"
+	statusMgr.route.parentsStatusesRecords[*parentRef.Namespace+"/"+parentRef.Name] = parentStatusRecord
 }
 
-// SetRouteReasonBackendNotFound sets the msg and the reason RouteReasonInvalidKind for the current tcp route pushed by PrepareTCPRouteStatus.
+// SetRouteReasonBackendNotFound sets the msg and the reason RouteReasonInvalidKind for the current route pushed by PrepareTCPRouteStatus, PrepareHTTPRouteStatus or PrepareTLSRouteStatus.
 func (statusMgr *StatusManagerImpl) SetRouteReasonInvalidKind(msg string) {
-	statusMgr.tcproute.generalConditions[RouteReasonInvalidKind] = msg
+	statusMgr.route.generalConditions[RouteReasonInvalidKind] = msg
+}
+
+// SetRouteReasonUnsupportedValue sets the msg and the reason RouteReasonUnsupportedValue for the current route pushed by PrepareHTTPRouteStatus.
+func (statusMgr *StatusManagerImpl) SetRouteReasonUnsupportedValue(msg string) {
+	statusMgr.route.generalConditions[RouteReasonUnsupportedValue] += msg + "\n"
 }
 
 // SetGatewayClassConditionStatusAccepted adds the provided gatewayclass to the list of accepted gatewayclasses.
 func (statusMgr *StatusManagerImpl) SetGatewayClassConditionStatusAccepted(gwClass store.GatewayClass) {
-	statusMgr.gatewayclasses = append(statusMgr.gatewayclasses, gwClass)
+	statusMgr.gatewayclasses = append(statusMgr.gatewayclasses, gatewayclassStatusRecord{gatewayclass: gwClass})
+}
+
+// SetGatewayClassReasonInvalidParameters adds the provided gatewayclass to the list of gatewayclasses not accepted because of their parametersRef.
+func (statusMgr *StatusManagerImpl) SetGatewayClassReasonInvalidParameters(gwClass store.GatewayClass, msg string) {
+	statusMgr.gatewayclasses = append(statusMgr.gatewayclasses, gatewayclassStatusRecord{gatewayclass: gwClass, invalidParameters: msg})
 }
 
-// AddManagedParentRef adds the parentref inside a new parentrefStatusRecord for the current tcp route.
+// AddManagedParentRef adds the parentref inside a new parentrefStatusRecord for the current route.
 func (statusMgr *StatusManagerImpl) AddManagedParentRef(parentRef store.ParentRef) {
-	statusMgr.tcproute.parentsStatusesRecords[*parentRef.Namespace+"/"+parentRef.Name] = parentrefStatusRecord{
+	statusMgr.route.parentsStatusesRecords[*parentRef.Namespace+"/"+parentRef.Name] = parentrefStatusRecord{
 		parentRef: parentRef,
 	}
 }
@@ -0,0 +1,207 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gateway
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/haproxytech/client-native/v6/models"
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/instance"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/maps"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/rules"
+	"github.com/haproxytech/kubernetes-ingress/pkg/route"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+// manageTLSRoutes creates backends from tlsroutes and attaches them to corresponding frontends according attachment rules.
+// TLS connections are routed on their SNI through the SNI map, as it is done for ssl-passthrough ingresses.
+func (gm GatewayManagerImpl) manageTLSRoutes() {
+	// Multiple routes can refer to the same listener (on the same gateway) with the same hostname.
+	// If so the oldest route is elected, so routes are all collected before being attached.
+	attachedRoutes := store.TLSRoutes{}
+	listenersByAttachedRoute := map[string][]store.Listener{}
+	for _, ns := range gm.k8sStore.Namespaces {
+		if !ns.Relevant {
+			logger.Debugf("gwapi: skipping namespace '%s'", ns.Name)
+			continue
+		}
+		logger.Debugf("gwapi: namespace '%s' has %d tlsroutes", ns.Name, len(ns.TLSRoutes))
+		for tlsroutename, tlsroute := range ns.TLSRoutes {
+			if tlsroute == nil {
+				logger.Warningf("gwapi: nil tlsroute under name '%s'", tlsroutename)
+				continue
+			}
+			routeKey := getTLSRouteKey(*tlsroute)
+			backendName := getTLSRouteBackendName(*tlsroute)
+			if tlsroute.Status == store.DELETED {
+				delete(ns.TLSRoutes, tlsroute.Name)
+				delete(gm.listenersByRoute, routeKey)
+				delete(gm.backends, backendName)
+				delete(gm.serversByBackend, backendName)
+				instance.Reload("tlsroute '%s/%s' deleted", tlsroute.Namespace, tlsroute.Name)
+				continue
+			}
+			gm.statusManager.PrepareTLSRouteStatusRecord(*tlsroute)
+
+			// Get the list of listeners (frontends) this tlsroute wants to be attached to.
+			listeners, errListeners := gm.getOurListenersFromRoute(K8S_TLSROUTE_KIND, tlsroute.Namespace, tlsroute.Name, tlsroute.ParentRefs, tlsroute.Hostnames)
+			logger.Error(errListeners)
+			previousAssociatedListeners := gm.listenersByRoute[routeKey]
+			gm.listenersByRoute[routeKey] = listeners
+
+			instance.ReloadIf(((len(listeners) != 0 || len(listeners) == 0 && len(previousAssociatedListeners) != 0) &&
+				!utils.EqualSliceByIDFunc(listeners, previousAssociatedListeners, extractNameFromListener)),
+				"modification in listeners for tlsroute '%s/%s'", tlsroute.Namespace, tlsroute.Name)
+
+			if len(listeners) == 0 {
+				continue
+			}
+
+			// Nothing to do to delete the corresponding backend as an automatic mechanism will remove it.
+			if len(tlsroute.BackendRefs) == 0 {
+				logger.Warningf("gwapi: no backendrefs in tlsroute '%s/%s'", tlsroute.Namespace, tlsroute.Name)
+				_, backendExists := gm.backends[backendName]
+				instance.ReloadIf(backendExists, "modification in backend for tlsroute '%s/%s'", tlsroute.Namespace, tlsroute.Name)
+				delete(gm.backends, backendName)
+				continue
+			}
+
+			// If not called on the route, the afferent backend will be automatically deleted.
+			gm.createRouteBackend(backendName, "tcp", listeners)
+			_, backendExists := gm.backends[backendName]
+			instance.ReloadIf(!backendExists, "modification in backend for tlsroute '%s/%s'", tlsroute.Namespace, tlsroute.Name)
+			gm.backends[backendName] = struct{}{}
+			// Adds the servers to the backends
+			reloadServers, errServers := gm.addServersToBackend(backendName, K8S_TLSROUTE_KIND, tlsroute.Namespace, tlsroute.Name, tlsroute.BackendRefs)
+			instance.ReloadIf(reloadServers, "modification in servers of backend '%s' from tlsroute '%s/%s'", backendName, tlsroute.Namespace, tlsroute.Name)
+			logger.Error(errServers)
+
+			attachedRoutes = append(attachedRoutes, *tlsroute)
+			listenersByAttachedRoute[routeKey] = listeners
+		}
+	}
+
+	// The oldest route is elected for each listener and hostname.
+	sort.SliceStable(attachedRoutes, attachedRoutes.Less)
+	sniKeys := map[string]struct{}{}
+	for _, tlsroute := range attachedRoutes {
+		for _, listener := range listenersByAttachedRoute[getTLSRouteKey(tlsroute)] {
+			frontendName := getFrontendName(listener)
+			for _, hostname := range getListenerHostnames(listener, tlsroute.Hostnames) {
+				key := getSNIMapKey(frontendName, hostname)
+				if _, ok := sniKeys[key]; ok {
+					continue
+				}
+				sniKeys[key] = struct{}{}
+				if hostname == "" {
+					// Connections with any SNI are routed to the default backend of the listener.
+					logger.Error(gm.setDefaultBackend(frontendName, getTLSRouteBackendName(tlsroute)))
+					continue
+				}
+				gm.maps.MapAppend(route.SNI, key+"\t\t\t"+getTLSRouteBackendName(tlsroute))
+			}
+			// the counter of attached routes for listener status is incremented.
+			gm.statusManager.IncrementRouteForListener(listener)
+		}
+	}
+}
+
+// addSNIRulesToFrontend creates on a TLS frontend the rules electing the backend from the SNI map.
+// The SNI map is shared with ssl-passthrough ingresses so the lookup key is scoped to the frontend.
+// When TLS is terminated, the SNI is the one of the deciphered connection instead of the one of the client hello.
+func (gm GatewayManagerImpl) addSNIRulesToFrontend(frontendName string, tlsTerminate bool) error {
+	frontend, err := gm.haproxyClient.FrontendGet(frontendName)
+	if err != nil {
+		return err
+	}
+	var sniRules []rules.Rule
+	if tlsTerminate {
+		sniRules = terminatedSNIRules("@" + frontendName)
+	} else {
+		inspectTimeout, errTimeout := annotations.Timeout("timeout-client", gm.k8sStore.ConfigMaps.Main.Annotations)
+		if inspectTimeout == nil {
+			if errTimeout != nil {
+				logger.Errorf("gwapi: frontend '%s': %s", frontendName, errTimeout)
+			}
+			inspectTimeout = utils.PtrInt64(5000)
+		}
+		sniRules = route.SNIRules(inspectTimeout, "@"+frontendName)
+	}
+	var errs utils.Errors
+	// Rules are inserted on top of the list so they are created in reverse order.
+	for i := len(sniRules) - 1; i >= 0; i-- {
+		errs.Add(sniRules[i].Create(gm.haproxyClient, &frontend, ""))
+	}
+	errs.Add(gm.haproxyClient.BackendSwitchingRuleCreate(0, frontendName, models.BackendSwitchingRule{
+		Name:     "%[var(txn.sni_match)]",
+		Cond:     "if",
+		CondTest: "{ var(txn.sni_match) -m found }",
+	}))
+	return errs.Result()
+}
+
+// terminatedSNIRules returns the rules electing the backend of a deciphered TLS connection from its SNI with the SNI map.
+func terminatedSNIRules(keySuffix string) []rules.Rule {
+	return []rules.Rule{
+		rules.ReqSetVar{
+			Name:       "sni",
+			Scope:      "sess",
+			Expression: "ssl_fc_sni",
+		},
+		rules.ReqSetVar{
+			Name:       "sni_match",
+			Scope:      "txn",
+			Expression: fmt.Sprintf("ssl_fc_sni,concat(%s),map(%s)", keySuffix, maps.GetPath(route.SNI)),
+		},
+		rules.ReqSetVar{
+			Name:       "sni_match",
+			Scope:      "txn",
+			Expression: fmt.Sprintf("ssl_fc_sni,regsub(^[^.]*,,),concat(%s),map(%s)", keySuffix, maps.GetPath(route.SNI)),
+			CondTest:   "!{ var(txn.sni_match) -m found }",
+		},
+	}
+}
+
+// setDefaultBackend sets the default backend of the frontend.
+func (gm GatewayManagerImpl) setDefaultBackend(frontendName, backendName string) error {
+	frontend, err := gm.haproxyClient.FrontendGet(frontendName)
+	if err != nil {
+		return err
+	}
+	frontend.DefaultBackend = backendName
+	return gm.haproxyClient.FrontendEdit(frontend.FrontendBase)
+}
+
+// getSNIMapKey provides the key of the hostname in the SNI map for the frontend.
+// Wildcard hostnames are stored without their '*' as the wildcard lookup strips the first label of the SNI.
+func getSNIMapKey(frontendName, hostname string) string {
+	if hostname != "" && hostname[0] == '*' {
+		hostname = hostname[1:]
+	}
+	return hostname + "@" + frontendName
+}
+
+// getTLSRouteKey provides the key of the tlsroute in the listeners by route bookkeeping.
+func getTLSRouteKey(tlsroute store.TLSRoute) string {
+	return K8S_TLSROUTE_KIND + "/" + tlsroute.Namespace + "/" + tlsroute.Name
+}
+
+// getTLSRouteBackendName provides backend name from tlsroute attributes.
+func getTLSRouteBackendName(tlsroute store.TLSRoute) string {
+	return tlsroute.Namespace + "_" + tlsroute.Name + "_tls"
+}
@@ -25,11 +25,13 @@ import (
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
-// UpdateStatusGatewayclasses is responsible of updating the statuses of the accepted gateway classes.
-func (statusMgr *StatusManagerImpl) UpdateStatusGatewayclasses(gatewayclasses []store.GatewayClass) {
+// UpdateStatusGatewayclasses is responsible of updating the statuses of the managed gateway classes.
+// A gatewayclass is not accepted if its parametersRef is invalid.
+func (statusMgr *StatusManagerImpl) UpdateStatusGatewayclasses(gatewayclasses []gatewayclassStatusRecord) {
 	transitionTime := metav1.NewTime(time.Now())
-	for _, gwClass := range gatewayclasses {
-		if gwClass.Status == store.EMPTY || gwClass.Status == store.DELETED {
+	for _, record := range gatewayclasses {
+		gwClass := record.gatewayclass
+		if gwClass.Status == store.DELETED {
 			continue
 		}
 		gwc := &v1beta1.GatewayClass{}
@@ -41,14 +43,20 @@ func (statusMgr *StatusManagerImpl) UpdateStatusGatewayclasses(gatewayclasses []
 			continue
 		}
 
+		condition := metav1.Condition{
+			Type:               GatewayClassConditionStatusAccepted,
+			ObservedGeneration: gwc.Generation,
+			LastTransitionTime: transitionTime,
+			Status:             metav1.ConditionTrue,
+			Reason:             GatewayClassReasonAccepted,
+		}
+		if record.invalidParameters != "" {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = GatewayClassReasonInvalidParameters
+			condition.Message = record.invalidParameters
+		}
 		gwc.Status = v1beta1.GatewayClassStatus{
-			Conditions: []metav1.Condition{{
-				Type:               GatewayClassConditionStatusAccepted,
-				ObservedGeneration: gwc.Generation,
-				LastTransitionTime: transitionTime,
-				Status:             metav1.ConditionTrue,
-				Reason:             GatewayClassReasonAccepted,
-			}},
+			Conditions: []metav1.Condition{condition},
 		}
 
 		logger.Error(statusMgr.k8sRestClient.Status().Update(context.TODO(), gwc))
@@ -62,7 +70,8 @@ func (statusMgr *StatusManagerImpl) UpdateStatusGateways(gatewayStatusRecords []
 	transitionTime := metav1.NewTime(time.Now())
 	for _, gatewayStatusRecord := range gatewayStatusRecords {
 		numRoutesHasChanged := hasNumberOfRoutesForAnyListenerChanged(gatewayStatusRecord, numRoutesByListenerByGateway, previousNumRoutesByListenerByGateway)
-		if !numRoutesHasChanged && (gatewayStatusRecord.status == store.EMPTY || gatewayStatusRecord.status == store.DELETED) {
+		if !numRoutesHasChanged && !gatewayStatusRecord.addressesChanged &&
+			(gatewayStatusRecord.status == store.EMPTY || gatewayStatusRecord.status == store.DELETED) {
 			continue
 		}
 
@@ -76,6 +85,17 @@ func (statusMgr *StatusManagerImpl) UpdateStatusGateways(gatewayStatusRecords []
 				Reason:             GatewayReasonReady,
 			}},
 		}
+		for _, address := range gatewayStatusRecord.addresses {
+			gwStatus.Addresses = append(gwStatus.Addresses, v1beta1.GatewayAddress{
+				Type:  (*v1beta1.AddressType)(address.Type),
+				Value: address.Value,
+			})
+		}
+		if gatewayStatusRecord.addressNotAssigned != "" {
+			gwStatus.Conditions[0].Status = metav1.ConditionFalse
+			gwStatus.Conditions[0].Reason = GatewayReasonAddressNotAssigned
+			gwStatus.Conditions[0].Message = gatewayStatusRecord.addressNotAssigned
+		}
 		for i, listenerStatusRecord := range gatewayStatusRecord.listenersStatusesRecords {
 			listenerConditions := []metav1.Condition{}
 			var numRoutes int32
@@ -103,15 +123,16 @@ func (statusMgr *StatusManagerImpl) UpdateStatusGateways(gatewayStatusRecords []
 				ObservedGeneration: gatewayStatusRecord.generation,
 				LastTransitionTime: transitionTime,
 			}
-			if msg, ok := listenerStatusRecord.reasons[ListenerReasonInvalidRouteKinds]; ok {
-				condition.Message = msg
-				condition.Reason = ListenerReasonInvalidRouteKinds
-				condition.Status = metav1.ConditionFalse
-				conditionReady.Status = metav1.ConditionFalse
-			} else {
-				condition.Message = msg
-				condition.Reason = ListenerReasonResolvedRefs
-				condition.Status = metav1.ConditionTrue
+			condition.Reason = ListenerReasonResolvedRefs
+			condition.Status = metav1.ConditionTrue
+			for _, reason := range []string{ListenerReasonInvalidRouteKinds, ListenerReasonInvalidCertificateRef, ListenerReasonRefNotPermitted} {
+				if msg, ok := listenerStatusRecord.reasons[reason]; ok {
+					condition.Message = msg
+					condition.Reason = reason
+					condition.Status = metav1.ConditionFalse
+					conditionReady.Status = metav1.ConditionFalse
+					break
+				}
 			}
 			listenerConditions = append(listenerConditions, condition)
 
@@ -151,91 +172,174 @@ func (statusMgr *StatusManagerImpl) UpdateStatusGateways(gatewayStatusRecords []
 	}
 }
 
-// UpdateStatusTCPRoutes is responsible of updating the statuses of the  tcp routes.
-func (statusMgr *StatusManagerImpl) UpdateStatusTCPRoutes(routesStatusRecords []routeStatusRecord) {
+// UpdateStatusRoutes is responsible of updating the statuses of the routes.
+func (statusMgr *StatusManagerImpl) UpdateStatusRoutes(routesStatusRecords []routeStatusRecord) {
 	transitionTime := metav1.NewTime(time.Now())
-	for _, tcprouteStatusRecord := range routesStatusRecords {
-		if tcprouteStatusRecord.status == store.EMPTY || tcprouteStatusRecord.status == store.DELETED {
+	for _, routeStatusRecord := range routesStatusRecords {
+		if routeStatusRecord.status == store.EMPTY || routeStatusRecord.status == store.DELETED {
 			continue
 		}
-
-		tcprouteStatus := v1alpha2.TCPRouteStatus{
-			RouteStatus: v1alpha2.RouteStatus{
-				Parents: []v1alpha2.RouteParentStatus{},
-			},
-		}
-		tcproute := &v1alpha2.TCPRoute{}
-		err := statusMgr.k8sRestClient.Get(context.TODO(), types.NamespacedName{
-			Namespace: tcprouteStatusRecord.namespace,
-			Name:      tcprouteStatusRecord.name,
-		}, tcproute)
-		if err != nil {
-			logger.Error(err)
-			continue
+		switch routeStatusRecord.kind {
+		case K8S_TCPROUTE_KIND:
+			statusMgr.updateStatusTCPRoute(routeStatusRecord, transitionTime)
+		case K8S_HTTPROUTE_KIND:
+			statusMgr.updateStatusHTTPRoute(routeStatusRecord, transitionTime)
+		case K8S_TLSROUTE_KIND:
+			statusMgr.updateStatusTLSRoute(routeStatusRecord, transitionTime)
 		}
+	}
+}
 
-		for _, parentStatusRecord := range tcprouteStatusRecord.parentsStatusesRecords {
-			conditions := []metav1.Condition{}
-			routeParentStatus := v1alpha2.RouteParentStatus{
-				ControllerName: v1alpha2.GatewayController(statusMgr.gatewayControllerName),
-				ParentRef: v1alpha2.ParentReference{
-					Group:       (*v1alpha2.Group)(&parentStatusRecord.parentRef.Group),
-					Kind:        (*v1alpha2.Kind)(&parentStatusRecord.parentRef.Kind),
-					Namespace:   (*v1alpha2.Namespace)(parentStatusRecord.parentRef.Namespace),
-					Name:        v1alpha2.ObjectName(parentStatusRecord.parentRef.Name),
-					SectionName: (*v1alpha2.SectionName)(parentStatusRecord.parentRef.SectionName),
-					Port:        (*v1alpha2.PortNumber)(parentStatusRecord.parentRef.Port),
-				},
-			}
+// updateStatusTCPRoute is responsible of updating the status of a tcp route.
+func (statusMgr *StatusManagerImpl) updateStatusTCPRoute(tcprouteStatusRecord routeStatusRecord, transitionTime metav1.Time) {
+	tcprouteStatus := v1alpha2.TCPRouteStatus{}
+	tcproute := &v1alpha2.TCPRoute{}
+	err := statusMgr.k8sRestClient.Get(context.TODO(), types.NamespacedName{
+		Namespace: tcprouteStatusRecord.namespace,
+		Name:      tcprouteStatusRecord.name,
+	}, tcproute)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
 
-			// RouteConditionAccepted
-			condition := metav1.Condition{
-				Type:               RouteConditionAccepted,
-				ObservedGeneration: tcprouteStatusRecord.generation,
-				LastTransitionTime: transitionTime,
-			}
-			if msg, ok := parentStatusRecord.reasons[RouteReasonNotAllowedByListeners]; ok {
-				condition.Status = metav1.ConditionFalse
-				condition.Message = msg
-				condition.Reason = RouteReasonNotAllowedByListeners
-			} else {
-				condition.Status = metav1.ConditionTrue
-				condition.Reason = RouteReasonAccepted
-			}
-			conditions = append(conditions, condition)
+	tcprouteStatus.Parents = statusMgr.getV1alpha2RouteParentStatuses(tcprouteStatusRecord, transitionTime)
 
-			// RouteConditionResolvedRefs
-			condition = metav1.Condition{
-				Type:               RouteConditionResolvedRefs,
-				ObservedGeneration: tcprouteStatusRecord.generation,
-				LastTransitionTime: transitionTime,
-				Status:             metav1.ConditionTrue,
-				Reason:             RouteReasonResolvedRefs,
-			}
+	tcproute.Status = tcprouteStatus
+	err = statusMgr.k8sRestClient.Status().Update(context.TODO(), tcproute)
+	logger.Error(err)
+}
 
-			if msg, ok := tcprouteStatusRecord.generalConditions[RouteReasonRefNotPermitted]; ok {
-				condition.Status = metav1.ConditionFalse
-				condition.Message = msg
-				condition.Reason = RouteReasonRefNotPermitted
-			} else if msg, ok := tcprouteStatusRecord.generalConditions[RouteReasonInvalidKind]; ok {
-				condition.Status = metav1.ConditionFalse
-				condition.Message = msg
-				condition.Reason = RouteReasonInvalidKind
-			} else if msg, ok := tcprouteStatusRecord.generalConditions[RouteReasonBackendNotFound]; ok {
-				condition.Status = metav1.ConditionFalse
-				condition.Message = msg
-				condition.Reason = RouteReasonBackendNotFound
-			}
-			conditions = append(conditions, condition)
+// updateStatusTLSRoute is responsible of updating the status of a tls route.
+func (statusMgr *StatusManagerImpl) updateStatusTLSRoute(tlsrouteStatusRecord routeStatusRecord, transitionTime metav1.Time) {
+	tlsroute := &v1alpha2.TLSRoute{}
+	err := statusMgr.k8sRestClient.Get(context.TODO(), types.NamespacedName{
+		Namespace: tlsrouteStatusRecord.namespace,
+		Name:      tlsrouteStatusRecord.name,
+	}, tlsroute)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
 
-			routeParentStatus.Conditions = conditions
-			tcprouteStatus.Parents = append(tcprouteStatus.Parents, routeParentStatus)
+	tlsroute.Status = v1alpha2.TLSRouteStatus{
+		RouteStatus: v1alpha2.RouteStatus{
+			Parents: statusMgr.getV1alpha2RouteParentStatuses(tlsrouteStatusRecord, transitionTime),
+		},
+	}
+	err = statusMgr.k8sRestClient.Status().Update(context.TODO(), tlsroute)
+	logger.Error(err)
+}
+
+// getV1alpha2RouteParentStatuses provides the parent statuses of the v1alpha2 routes (tcproute, tlsroute).
+func (statusMgr *StatusManagerImpl) getV1alpha2RouteParentStatuses(routeStatusRecord routeStatusRecord, transitionTime metav1.Time) []v1alpha2.RouteParentStatus {
+	routeParentStatuses := []v1alpha2.RouteParentStatus{}
+	for _, parentStatusRecord := range routeStatusRecord.parentsStatusesRecords {
+		routeParentStatus := v1alpha2.RouteParentStatus{
+			ControllerName: v1alpha2.GatewayController(statusMgr.gatewayControllerName),
+			ParentRef: v1alpha2.ParentReference{
+				Group:       (*v1alpha2.Group)(&parentStatusRecord.parentRef.Group),
+				Kind:        (*v1alpha2.Kind)(&parentStatusRecord.parentRef.Kind),
+				Namespace:   (*v1alpha2.Namespace)(parentStatusRecord.parentRef.Namespace),
+				Name:        v1alpha2.ObjectName(parentStatusRecord.parentRef.Name),
+				SectionName: (*v1alpha2.SectionName)(parentStatusRecord.parentRef.SectionName),
+				Port:        (*v1alpha2.PortNumber)(parentStatusRecord.parentRef.Port),
+			},
+			Conditions: getRouteParentConditions(routeStatusRecord, parentStatusRecord, transitionTime),
 		}
+		routeParentStatuses = append(routeParentStatuses, routeParentStatus)
+	}
+	return routeParentStatuses
+}
 
-		tcproute.Status = tcprouteStatus
-		err = statusMgr.k8sRestClient.Status().Update(context.TODO(), tcproute)
+// updateStatusHTTPRoute is responsible of updating the status of a http route.
+func (statusMgr *StatusManagerImpl) updateStatusHTTPRoute(httprouteStatusRecord routeStatusRecord, transitionTime metav1.Time) {
+	httprouteStatus := v1beta1.HTTPRouteStatus{
+		RouteStatus: v1beta1.RouteStatus{
+			Parents: []v1beta1.RouteParentStatus{},
+		},
+	}
+	httproute := &v1beta1.HTTPRoute{}
+	err := statusMgr.k8sRestClient.Get(context.TODO(), types.NamespacedName{
+		Namespace: httprouteStatusRecord.namespace,
+		Name:      httprouteStatusRecord.name,
+	}, httproute)
+	if err != nil {
 		logger.Error(err)
+		return
+	}
+
+	for _, parentStatusRecord := range httprouteStatusRecord.parentsStatusesRecords {
+		routeParentStatus := v1beta1.RouteParentStatus{
+			ControllerName: v1beta1.GatewayController(statusMgr.gatewayControllerName),
+			ParentRef: v1beta1.ParentReference{
+				Group:       (*v1beta1.Group)(&parentStatusRecord.parentRef.Group),
+				Kind:        (*v1beta1.Kind)(&parentStatusRecord.parentRef.Kind),
+				Namespace:   (*v1beta1.Namespace)(parentStatusRecord.parentRef.Namespace),
+				Name:        v1beta1.ObjectName(parentStatusRecord.parentRef.Name),
+				SectionName: (*v1beta1.SectionName)(parentStatusRecord.parentRef.SectionName),
+				Port:        (*v1beta1.PortNumber)(parentStatusRecord.parentRef.Port),
+			},
+			Conditions: getRouteParentConditions(httprouteStatusRecord, parentStatusRecord, transitionTime),
+		}
+		httprouteStatus.Parents = append(httprouteStatus.Parents, routeParentStatus)
+	}
+
+	httproute.Status = httprouteStatus
+	err = statusMgr.k8sRestClient.Status().Update(context.TODO(), httproute)
+	logger.Error(err)
+}
+
+// getRouteParentConditions returns the conditions of a route for one of its parents, they are common to every route kind.
+func getRouteParentConditions(routeStatusRecord routeStatusRecord, parentStatusRecord parentrefStatusRecord, transitionTime metav1.Time) []metav1.Condition {
+	conditions := []metav1.Condition{}
+	// RouteConditionAccepted
+	condition := metav1.Condition{
+		Type:               RouteConditionAccepted,
+		ObservedGeneration: routeStatusRecord.generation,
+		LastTransitionTime: transitionTime,
+	}
+	if msg, ok := parentStatusRecord.reasons[RouteReasonNotAllowedByListeners]; ok {
+		condition.Status = metav1.ConditionFalse
+		condition.Message = msg
+		condition.Reason = RouteReasonNotAllowedByListeners
+	} else if msg, ok := parentStatusRecord.reasons[RouteReasonNoMatchingListenerHostname]; ok {
+		condition.Status = metav1.ConditionFalse
+		condition.Message = msg
+		condition.Reason = RouteReasonNoMatchingListenerHostname
+	} else if msg, ok := routeStatusRecord.generalConditions[RouteReasonUnsupportedValue]; ok {
+		condition.Status = metav1.ConditionFalse
+		condition.Message = msg
+		condition.Reason = RouteReasonUnsupportedValue
+	} else {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = RouteReasonAccepted
+	}
+	conditions = append(conditions, condition)
+
+	// RouteConditionResolvedRefs
+	condition = metav1.Condition{
+		Type:               RouteConditionResolvedRefs,
+		ObservedGeneration: routeStatusRecord.generation,
+		LastTransitionTime: transitionTime,
+		Status:             metav1.ConditionTrue,
+		Reason:             RouteReasonResolvedRefs,
+	}
+
+	if msg, ok := routeStatusRecord.generalConditions[RouteReasonRefNotPermitted]; ok {
+		condition.Status = metav1.ConditionFalse
+		condition.Message = msg
+		condition.Reason = RouteReasonRefNotPermitted
+	} else if msg, ok := routeStatusRecord.generalConditions[RouteReasonInvalidKind]; ok {
+		condition.Status = metav1.ConditionFalse
+		condition.Message = msg
+		condition.Reason = RouteReasonInvalidKind
+	} else if msg, ok := routeStatusRecord.generalConditions[RouteReasonBackendNotFound]; ok {
+		condition.Status = metav1.ConditionFalse
+		condition.Message = msg
+		condition.Reason = RouteReasonBackendNotFound
 	}
+	return append(conditions, condition)
 }
 
 // hasNumberOfRoutesForAnyListenerChanged returns if the number of attached routes has changed for any listener of the provided gateways.
@@ -0,0 +1,69 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/errorpages"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/instance"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+)
+
+// ErrorPages writes the error pages of the labeled ConfigMaps used by the http-error rules of the backends
+// having the error-pages annotation.
+type ErrorPages struct {
+	files files
+}
+
+func (handler *ErrorPages) Update(k store.K8s, h haproxy.HAProxy, a annotations.Annotations) (err error) {
+	handler.files.dir = h.Env.ErrorPagesDir
+	for _, ns := range k.Namespaces {
+		for _, cm := range ns.ConfigMaps {
+			for code, content := range cm.Annotations {
+				if errorpages.CheckCode(code) != nil {
+					continue
+				}
+				name := errorpages.FileName(cm.Namespace, cm.Name, code)
+				if err = os.MkdirAll(filepath.Dir(filepath.Join(handler.files.dir, name)), 0o755); err == nil {
+					err = handler.files.writeFile(name, content)
+				}
+				if err != nil {
+					logger.Errorf("failed writing error page '%s': %s", name, err)
+				}
+			}
+		}
+	}
+
+	for name, f := range handler.files.data {
+		if !f.inUse {
+			err = handler.files.deleteFile(name)
+			if err != nil {
+				logger.Errorf("failed deleting error page '%s': %s", name, err)
+			}
+			// The directory of the ConfigMap is only removed once empty.
+			_ = os.Remove(filepath.Dir(filepath.Join(handler.files.dir, name)))
+			continue
+		}
+
+		instance.ReloadIf(f.updated, "error page '%s' updated: reload required", name)
+		f.inUse = false
+		f.updated = false
+	}
+	return nil
+}
@@ -26,9 +26,6 @@ import (
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/certs"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/instance"
-
-	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/maps"
-	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/rules"
 	"github.com/haproxytech/kubernetes-ingress/pkg/route"
 	"github.com/haproxytech/kubernetes-ingress/pkg/store"
 	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
@@ -158,6 +155,29 @@ func (handler *HTTPS) handleClientTLSAuth(k store.K8s, h haproxy.HAProxy) (err e
 	return err
 }
 
+// handleCrtList sets the crt-list of the certificates served with their own client certificate verification.
+func (handler *HTTPS) handleCrtList(h haproxy.HAProxy) error {
+	crtList := h.UpdateCrtList()
+	if !h.FrontendSSLOffloadEnabled(h.FrontHTTPS) {
+		crtList = ""
+	}
+	binds, err := h.FrontendBindsGet(h.FrontHTTPS)
+	if err != nil {
+		return fmt.Errorf("crt-list: %w", err)
+	}
+	for _, bind := range binds {
+		if bind.CrtList == crtList {
+			continue
+		}
+		bind.CrtList = crtList
+		if err = h.FrontendBindEdit(h.FrontHTTPS, *bind); err != nil {
+			return fmt.Errorf("crt-list: %w", err)
+		}
+		instance.Reload("crt-list of bind '%s' updated", bind.Name)
+	}
+	return nil
+}
+
 func (handler *HTTPS) Update(k store.K8s, h haproxy.HAProxy, a annotations.Annotations) (err error) {
 	if !handler.Enabled {
 		logger.Debug("Cannot proceed with SSL Passthrough update, HTTPS is disabled")
@@ -217,6 +237,9 @@ func (handler *HTTPS) Update(k store.K8s, h haproxy.HAProxy, a annotations.Annot
 		instance.Reload("SSLPassthrough disabled")
 	}
 
+	// crt-list
+	logger.Error(handler.handleCrtList(h))
+
 	instance.ReloadIf(h.CertsUpdated(), "certificates updated")
 
 	return nil
@@ -227,7 +250,7 @@ func (handler *HTTPS) enableSSLPassthrough(h haproxy.HAProxy) (err error) {
 	frontend := models.FrontendBase{
 		Name:           h.FrontSSL,
 		Mode:           "tcp",
-		LogFormat:      "'%ci:%cp [%t] %ft %b/%s %Tw/%Tc/%Tt %B %ts %ac/%fc/%bc/%sc/%rc %sq/%bq %hr %hs SNI: %[var(sess.sni)]'",
+		LogFormat:      route.SNILogFormat,
 		DefaultBackend: h.BackSSL,
 	}
 	err = h.FrontendCreate(frontend)
@@ -307,27 +330,8 @@ func (handler *HTTPS) sslPassthroughRules(k store.K8s, h haproxy.HAProxy, a anno
 		inspectTimeout = utils.PtrInt64(5000)
 	}
 	errors := utils.Errors{}
-	errors.Add(
-		h.Rules.AddRule(h.FrontSSL, rules.ReqAcceptContent{}, false),
-		h.Rules.AddRule(h.FrontSSL, rules.ReqInspectDelay{
-			Timeout: inspectTimeout,
-		}, false),
-		h.AddRule(h.FrontSSL, rules.ReqSetVar{
-			Name:       "sni",
-			Scope:      "sess",
-			Expression: "req_ssl_sni",
-		}, false),
-		h.AddRule(h.FrontSSL, rules.ReqSetVar{
-			Name:       "sni_match",
-			Scope:      "txn",
-			Expression: fmt.Sprintf("req_ssl_sni,map(%s)", maps.GetPath(route.SNI)),
-		}, false),
-		h.AddRule(h.FrontSSL, rules.ReqSetVar{
-			Name:       "sni_match",
-			Scope:      "txn",
-			Expression: fmt.Sprintf("req_ssl_sni,regsub(^[^.]*,,),map(%s)", maps.GetPath(route.SNI)),
-			CondTest:   "!{ var(txn.sni_match) -m found }",
-		}, false),
-	)
+	for _, rule := range route.SNIRules(inspectTimeout, "") {
+		errors.Add(h.AddRule(h.FrontSSL, rule, false))
+	}
 	return errors.Result()
 }
@@ -81,7 +81,8 @@ func (c *clientNative) FrontendCfgSnippetApply() error {
 
 func (c *clientNative) FrontendCreate(frontend models.FrontendBase) error {
 	oldFrontend, ok := c.frontends[frontend.Name]
-	if !ok {
+	// A nil frontend is pending deletion, it is created anew.
+	if !ok || oldFrontend == nil {
 		c.frontends[frontend.Name] = &Frontend{
 			Frontend: models.Frontend{
 				FrontendBase: frontend,
@@ -162,7 +163,7 @@ func (c *clientNative) FrontendsGet() (models.Frontends, error) {
 
 func (c *clientNative) FrontendGet(frontendName string) (models.Frontend, error) {
 	oldFrontend, ok := c.frontends[frontendName]
-	if ok {
+	if ok && oldFrontend != nil {
 		return oldFrontend.Frontend, nil
 	}
 	return models.Frontend{}, fmt.Errorf("frontend %s not found", frontendName)
@@ -179,7 +180,7 @@ func (c *clientNative) FrontendGet(frontendName string) (models.Frontend, error)
 
 func (c *clientNative) FrontendEdit(frontend models.FrontendBase) error {
 	oldFrontend, ok := c.frontends[frontend.Name]
-	if !ok {
+	if !ok || oldFrontend == nil {
 		return fmt.Errorf("can't edit unexisting frontend %s", frontend.Name)
 	}
 	oldFrontend.FrontendBase = frontend
@@ -222,6 +223,7 @@ func (c *clientNative) FrontendDisableSSLOffload(frontendName string) (err error
 		bind.SslCafile = ""
 		bind.Verify = ""
 		bind.SslCertificate = ""
+		bind.CrtList = ""
 		bind.CaSignFile = ""
 		bind.Alpn = ""
 		bind.StrictSni = false
@@ -0,0 +1,139 @@
+package certs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/google/renameio"
+	"github.com/haproxytech/client-native/v6/runtime"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/fs"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/instance"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+)
+
+// CrtListEntry is a certificate of the crt-list of the HTTPS frontend, served with its own client
+// certificate verification to the clients requesting one of the SNIs.
+type CrtListEntry struct {
+	SNIFilter []string
+	CAFile    string
+	CRLFile   string
+	// Verify is the verification of client certificates, "required" or "optional"
+	Verify string
+}
+
+// crtList is the crt-list of the HTTPS frontend, its entries being indexed by their line in the file.
+type crtList struct {
+	// entries are the entries added during the sync
+	entries map[string]runtime.CrtListEntry
+	// current are the entries of the crt-list loaded by HAProxy
+	current map[string]runtime.CrtListEntry
+}
+
+func (e CrtListEntry) sslBindConfig() string {
+	params := []string{"ca-file " + e.CAFile, "verify " + e.Verify}
+	if e.CRLFile != "" {
+		params = append(params, "crl-file "+e.CRLFile)
+	}
+	return strings.Join(params, " ")
+}
+
+func crtListLine(entry runtime.CrtListEntry) string {
+	return fmt.Sprintf("%s [%s] %s", entry.File, entry.SSLBindConfig, strings.Join(entry.SNIFilter, " "))
+}
+
+func crtListPath() string {
+	return filepath.Join(env.MainDir, "frontend.crt-list")
+}
+
+// AddCrtListEntry writes the certificate of the secret and adds it to the crt-list of the HTTPS frontend.
+func (c *certs) AddCrtListEntry(secret *store.Secret, entry CrtListEntry) error {
+	if len(entry.SNIFilter) == 0 {
+		return errors.New("crt-list entry without SNI")
+	}
+	certPath, err := c.AddSecret(secret, CRTLIST_CERT)
+	if err != nil {
+		return err
+	}
+	listEntry := runtime.CrtListEntry{
+		File:          certPath,
+		SSLBindConfig: entry.sslBindConfig(),
+		SNIFilter:     entry.SNIFilter,
+	}
+	c.crtList.entries[crtListLine(listEntry)] = listEntry
+	return nil
+}
+
+// AddCRL writes the certificate revocation list of the secret, in its "ca.crl" entry.
+func (c *certs) AddCRL(secret *store.Secret) (crlPath string, err error) {
+	if secret == nil {
+		return "", errors.New("nil secret")
+	}
+	content, ok := secret.Data["ca.crl"]
+	if !ok {
+		return "", fmt.Errorf("certificate revocation list missing in %s/%s", secret.Namespace, secret.Name)
+	}
+	crlName := fmt.Sprintf("%s_%s", secret.Namespace, secret.Name)
+	crlPath = filepath.Join(env.CrlDir, crlName+".pem")
+	if err = writeReloadedFile(c.crl, crlName, secret, crlPath, content); err != nil {
+		return "", err
+	}
+	return crlPath, nil
+}
+
+// UpdateCrtList applies the entries added during the sync to the crt-list of the HTTPS frontend and
+// returns its path, empty when there is no entry. New entries are added with the runtime API, any
+// other change requires a reload.
+func (c *certs) UpdateCrtList() string {
+	path := crtListPath()
+	added := make([]string, 0, len(c.crtList.entries))
+	for line := range c.crtList.entries {
+		if _, ok := c.crtList.current[line]; !ok {
+			added = append(added, line)
+		}
+	}
+	removed := len(c.crtList.current)+len(added) != len(c.crtList.entries)
+	switch {
+	case len(c.crtList.entries) == 0 && len(c.crtList.current) == 0:
+		return ""
+	case len(added) == 0 && !removed:
+		return path
+	case len(c.crtList.current) == 0 || removed:
+		instance.Reload("crt-list '%s' updated", path)
+	default:
+		sort.Strings(added)
+		// New certificates must be committed before being added to the crt-list
+		fs.Writer.WaitUntilWritesDone()
+		for _, line := range added {
+			if err := c.client.CrtListEntryAdd(path, c.crtList.entries[line]); err != nil {
+				instance.Reload("Runtime update of crt-list '%s' failed : %s", path, err)
+				break
+			}
+			logger.Debugf("`add ssl crt-list` ok [%s] [%s]", path, line)
+		}
+	}
+	c.crtList.current = c.crtList.entries
+
+	if len(c.crtList.entries) == 0 {
+		fs.AddDelayedFunc(path, func() {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				logger.Error(err)
+			}
+		})
+		return ""
+	}
+	lines := make([]string, 0, len(c.crtList.entries))
+	for line := range c.crtList.entries {
+		lines = append(lines, line)
+	}
+	sort.Strings(lines)
+	content := strings.Join(lines, "\n") + "\n"
+	fs.AddDelayedFunc(path, func() {
+		logger.Error(renameio.WriteFile(path, []byte(content), 0o644))
+	})
+	return path
+}
@@ -0,0 +1,79 @@
+package certs
+
+import (
+	"testing"
+
+	"github.com/haproxytech/client-native/v6/runtime"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/api"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/instance"
+)
+
+type mockCrtListClient struct {
+	api.HAProxyClient
+	added []runtime.CrtListEntry
+}
+
+func (m *mockCrtListClient) CrtListEntryAdd(crtList string, entry runtime.CrtListEntry) error {
+	m.added = append(m.added, entry)
+	return nil
+}
+
+func TestUpdateCrtList(t *testing.T) {
+	previousEnv := env
+	defer func() { env = previousEnv }()
+	env.MainDir = t.TempDir()
+	client := &mockCrtListClient{}
+	c := &certs{
+		client: client,
+		crtList: crtList{
+			entries: make(map[string]runtime.CrtListEntry),
+			current: make(map[string]runtime.CrtListEntry),
+		},
+	}
+	appEntry := runtime.CrtListEntry{File: "app.pem", SSLBindConfig: CrtListEntry{CAFile: "ca.pem", Verify: "required"}.sslBindConfig(), SNIFilter: []string{"app.example.local"}}
+	apiEntry := runtime.CrtListEntry{File: "api.pem", SSLBindConfig: CrtListEntry{CAFile: "ca.pem", Verify: "optional"}.sslBindConfig(), SNIFilter: []string{"api.example.local"}}
+
+	steps := []struct {
+		name    string
+		entries []runtime.CrtListEntry
+		path    bool
+		reload  bool
+		added   int
+	}{
+		{name: "no entry", entries: nil, path: false, reload: false},
+		{name: "first entry", entries: []runtime.CrtListEntry{appEntry}, path: true, reload: true},
+		{name: "same entries", entries: []runtime.CrtListEntry{appEntry}, path: true, reload: false},
+		{name: "added entry", entries: []runtime.CrtListEntry{appEntry, apiEntry}, path: true, reload: false, added: 1},
+		{name: "removed entry", entries: []runtime.CrtListEntry{apiEntry}, path: true, reload: true},
+		{name: "last entry removed", entries: nil, path: false, reload: true},
+	}
+	for _, step := range steps {
+		instance.Reset()
+		client.added = nil
+		for _, entry := range step.entries {
+			c.crtList.entries[crtListLine(entry)] = entry
+		}
+		path := c.UpdateCrtList()
+		if (path != "") != step.path {
+			t.Errorf("%s: unexpected crt-list path '%s'", step.name, path)
+		}
+		if instance.NeedReload() != step.reload {
+			t.Errorf("%s: expected reload %t", step.name, step.reload)
+		}
+		if len(client.added) != step.added {
+			t.Errorf("%s: expected %d runtime entries, got %d", step.name, step.added, len(client.added))
+		}
+		c.CleanCerts()
+	}
+	instance.Reset()
+}
+
+func TestCrtListLine(t *testing.T) {
+	entry := CrtListEntry{CAFile: "/ca.pem", CRLFile: "/crl.pem", Verify: "required"}
+	line := crtListLine(runtime.CrtListEntry{File: "/app.pem", SSLBindConfig: entry.sslBindConfig(), SNIFilter: []string{"app.example.local"}})
+	expected := "/app.pem [ca-file /ca.pem verify required crl-file /crl.pem] app.example.local"
+	if line != expected {
+		t.Errorf("expected '%s', got '%s'", expected, line)
+	}
+}
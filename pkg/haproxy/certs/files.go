@@ -0,0 +1,61 @@
+package certs
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/renameio"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/instance"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+)
+
+// writeReloadedFile writes the file of a secret when its content changes. Unlike certificates, these
+// files cannot be updated with the runtime API and are loaded by HAProxy at startup, so that a reload
+// is needed to use the updated content.
+func writeReloadedFile(files map[string]*cert, fileName string, secret *store.Secret, filePath string, content []byte) error {
+	file, ok := files[fileName]
+	if !ok {
+		file = &cert{
+			name: fmt.Sprintf("%s/%s", secret.Namespace, secret.Name),
+			path: filePath,
+		}
+		files[fileName] = file
+	}
+	file.inUse = true
+	current, err := os.ReadFile(filePath)
+	if err == nil && bytes.Equal(current, content) {
+		return nil
+	}
+	if err = renameio.WriteFile(filePath, content, 0o644); err != nil {
+		return err
+	}
+	instance.Reload("file '%s' of secret '%s' updated", filePath, file.name)
+	return nil
+}
+
+// refreshReloadedFiles removes the files of the directory no longer used.
+func refreshReloadedFiles(files map[string]*cert, dir string) {
+	if dir == "" {
+		return
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+	for _, f := range entries {
+		fileName := strings.TrimSuffix(f.Name(), ".pem")
+		if f.IsDir() || fileName+".pem" != f.Name() {
+			continue
+		}
+		if file, ok := files[fileName]; ok && file.inUse {
+			continue
+		}
+		logger.Error(os.Remove(filepath.Join(dir, f.Name())))
+		delete(files, fileName)
+	}
+}
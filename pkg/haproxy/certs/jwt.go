@@ -0,0 +1,184 @@
+package certs
+
+import (
+	"crypto/ecdh"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+)
+
+// JWTKey is a public key verifying the signature of JWTs, written in a PEM file.
+type JWTKey struct {
+	// Kid is the key ID of a JWKS key, empty for the keys of PEM entries.
+	Kid  string
+	Path string
+}
+
+// jwk is a key of a JWKS document, only RSA and EC public keys are supported.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// jwtKeyFileRegexp matches the characters not allowed in the name of a JWT key file.
+var jwtKeyFileRegexp = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// AddJWTKeys writes the public keys of the secret and returns them. Secret entries ending with ".json"
+// are JWKS documents, the other ones contain PEM public keys or certificates.
+func (c *certs) AddJWTKeys(secret *store.Secret) (keys []JWTKey, err error) {
+	if secret == nil {
+		return nil, errors.New("nil secret")
+	}
+	if env.JWTDir == "" {
+		return nil, errors.New("empty name for JWT keys Directory")
+	}
+	names := make([]string, 0, len(secret.Data))
+	for name := range secret.Data {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		var entryKeys []JWTKey
+		var contents [][]byte
+		if strings.HasSuffix(name, ".json") {
+			entryKeys, contents, err = jwksPublicKeys(secret.Data[name])
+		} else {
+			entryKeys, contents, err = pemPublicKeys(secret.Data[name])
+		}
+		if err != nil {
+			return nil, fmt.Errorf("secret '%s/%s', entry '%s': %w", secret.Namespace, secret.Name, name, err)
+		}
+		for i := range entryKeys {
+			id := entryKeys[i].Kid
+			if id == "" {
+				id = fmt.Sprintf("%s_%d", strings.TrimSuffix(name, filepath.Ext(name)), i)
+			}
+			keyName := jwtKeyFileRegexp.ReplaceAllString(fmt.Sprintf("%s_%s_%s", secret.Namespace, secret.Name, id), "_")
+			entryKeys[i].Path = filepath.Join(env.JWTDir, keyName+".pem")
+			if err = writeReloadedFile(c.jwt, keyName, secret, entryKeys[i].Path, contents[i]); err != nil {
+				return nil, err
+			}
+		}
+		keys = append(keys, entryKeys...)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no public key in secret '%s/%s'", secret.Namespace, secret.Name)
+	}
+	return keys, nil
+}
+
+// pemPublicKeys returns the public keys of the PEM blocks, public keys or certificates.
+func pemPublicKeys(data []byte) (keys []JWTKey, contents [][]byte, err error) {
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		var der []byte
+		switch block.Type {
+		case "PUBLIC KEY":
+			if _, err = x509.ParsePKIXPublicKey(block.Bytes); err != nil {
+				return nil, nil, err
+			}
+			der = block.Bytes
+		case "CERTIFICATE":
+			var crt *x509.Certificate
+			if crt, err = x509.ParseCertificate(block.Bytes); err != nil {
+				return nil, nil, err
+			}
+			if der, err = x509.MarshalPKIXPublicKey(crt.PublicKey); err != nil {
+				return nil, nil, err
+			}
+		default:
+			return nil, nil, fmt.Errorf("unsupported PEM block '%s', expected 'PUBLIC KEY' or 'CERTIFICATE'", block.Type)
+		}
+		keys = append(keys, JWTKey{})
+		contents = append(contents, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	}
+	if len(keys) == 0 {
+		return nil, nil, errors.New("no PEM block")
+	}
+	return keys, contents, nil
+}
+
+// jwksPublicKeys returns the RSA and EC signature keys of the JWKS document as PEM public keys.
+func jwksPublicKeys(data []byte) (keys []JWTKey, contents [][]byte, err error) {
+	var jwks struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err = json.Unmarshal(data, &jwks); err != nil {
+		return nil, nil, fmt.Errorf("invalid JWKS: %w", err)
+	}
+	for _, k := range jwks.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		var publicKey any
+		switch k.Kty {
+		case "RSA":
+			publicKey, err = k.rsaPublicKey()
+		case "EC":
+			publicKey, err = k.ecPublicKey()
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("key '%s': %w", k.Kid, err)
+		}
+		var der []byte
+		if der, err = x509.MarshalPKIXPublicKey(publicKey); err != nil {
+			return nil, nil, fmt.Errorf("key '%s': %w", k.Kid, err)
+		}
+		keys = append(keys, JWTKey{Kid: k.Kid})
+		contents = append(contents, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	}
+	return keys, contents, nil
+}
+
+func (k jwk) rsaPublicKey() (any, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modulus: %w", err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil || len(e) == 0 || len(e) > 4 {
+		return nil, errors.New("invalid exponent")
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+}
+
+func (k jwk) ecPublicKey() (any, error) {
+	var curve ecdh.Curve
+	var size int
+	switch k.Crv {
+	case "P-256":
+		curve, size = ecdh.P256(), 32
+	case "P-384":
+		curve, size = ecdh.P384(), 48
+	case "P-521":
+		curve, size = ecdh.P521(), 66
+	default:
+		return nil, fmt.Errorf("unsupported curve '%s'", k.Crv)
+	}
+	x, errX := base64.RawURLEncoding.DecodeString(k.X)
+	y, errY := base64.RawURLEncoding.DecodeString(k.Y)
+	if errX != nil || errY != nil || len(x) != size || len(y) != size {
+		return nil, errors.New("invalid coordinates")
+	}
+	// Uncompressed point encoding
+	point := append(append([]byte{4}, x...), y...)
+	return curve.NewPublicKey(point)
+}
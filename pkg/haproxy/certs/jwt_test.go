@@ -0,0 +1,150 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+)
+
+func parsePEMPublicKey(t *testing.T, content []byte) any {
+	t.Helper()
+	block, _ := pem.Decode(content)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		t.Fatalf("expected a PUBLIC KEY PEM block, got %q", content)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestJWKSPublicKeys(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b64 := base64.RawURLEncoding.EncodeToString
+	jwks, _ := json.Marshal(map[string][]jwk{"keys": {
+		{Kty: "RSA", Kid: "rsa-1", Use: "sig", N: b64(rsaKey.N.Bytes()), E: b64(big.NewInt(int64(rsaKey.E)).Bytes())},
+		{Kty: "EC", Kid: "ec-1", Crv: "P-384", X: b64(ecKey.X.FillBytes(make([]byte, 48))), Y: b64(ecKey.Y.FillBytes(make([]byte, 48)))},
+		{Kty: "RSA", Kid: "rsa-enc", Use: "enc", N: b64(rsaKey.N.Bytes()), E: b64(big.NewInt(int64(rsaKey.E)).Bytes())},
+		{Kty: "oct", Kid: "hmac"},
+	}})
+
+	keys, contents, err := jwksPublicKeys(jwks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 || keys[0].Kid != "rsa-1" || keys[1].Kid != "ec-1" {
+		t.Fatalf("unexpected keys %+v", keys)
+	}
+	if key, ok := parsePEMPublicKey(t, contents[0]).(*rsa.PublicKey); !ok || !key.Equal(&rsaKey.PublicKey) {
+		t.Error("RSA key does not match the JWKS key")
+	}
+	if key, ok := parsePEMPublicKey(t, contents[1]).(*ecdsa.PublicKey); !ok || !key.Equal(&ecKey.PublicKey) {
+		t.Error("EC key does not match the JWKS key")
+	}
+
+	if _, _, err = jwksPublicKeys([]byte(`{"keys":[{"kty":"EC","crv":"P-256","x":"AA","y":"AA"}]}`)); err == nil {
+		t.Error("expected an error for invalid EC coordinates")
+	}
+}
+
+func TestPEMPublicKeys(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "issuer"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	crt, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := append(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: crt}),
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})...)
+
+	keys, contents, err := pemPublicKeys(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 {
+		t.Fatalf("expected 2 keys, got %d", len(keys))
+	}
+	for _, content := range contents {
+		if publicKey, ok := parsePEMPublicKey(t, content).(*ecdsa.PublicKey); !ok || !publicKey.Equal(&key.PublicKey) {
+			t.Error("PEM key does not match the certificate key")
+		}
+	}
+
+	if _, _, err = pemPublicKeys([]byte("no pem")); err == nil {
+		t.Error("expected an error without PEM block")
+	}
+	if _, _, err = pemPublicKeys(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})); err == nil {
+		t.Error("expected an error for a private key")
+	}
+}
+
+func TestAddJWTKeys(t *testing.T) {
+	previousEnv := env
+	defer func() { env = previousEnv }()
+	env.JWTDir = t.TempDir()
+	c := &certs{jwt: make(map[string]*cert)}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := &store.Secret{
+		Namespace: "ns",
+		Name:      "jwt",
+		Data:      map[string][]byte{"key.pem": pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})},
+	}
+	keys, err := c.AddJWTKeys(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedPath := filepath.Join(env.JWTDir, "ns_jwt_key_0.pem")
+	if len(keys) != 1 || keys[0].Path != expectedPath {
+		t.Fatalf("unexpected keys %+v", keys)
+	}
+	if _, err = os.Stat(expectedPath); err != nil {
+		t.Fatal(err)
+	}
+
+	c.CleanCerts()
+	refreshReloadedFiles(c.jwt, env.JWTDir)
+	if _, err = os.Stat(expectedPath); !os.IsNotExist(err) {
+		t.Error("expected the unused key file to be removed")
+	}
+}
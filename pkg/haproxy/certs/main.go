@@ -24,6 +24,10 @@ type certs struct {
 	backend  map[string]*cert
 	ca       map[string]*cert
 	TCPCR    map[string]*cert
+	CRTLIST  map[string]*cert
+	jwt      map[string]*cert
+	crl      map[string]*cert
+	crtList  crtList
 	client   api.HAProxyClient
 	mu       *sync.Mutex
 }
@@ -31,6 +35,14 @@ type certs struct {
 type Certificates interface {
 	// Add takes a secret and its type and creats or updates the corresponding certificate
 	AddSecret(secret *store.Secret, secretType SecretType) (certPath string, err error)
+	// AddJWTKeys takes a secret of JWT verification keys and creates or updates their files
+	AddJWTKeys(secret *store.Secret) (keys []JWTKey, err error)
+	// AddCrtListEntry takes a secret and adds its certificate to the crt-list of the HTTPS frontend
+	AddCrtListEntry(secret *store.Secret, entry CrtListEntry) error
+	// AddCRL takes a secret and creates or updates the corresponding certificate revocation list
+	AddCRL(secret *store.Secret) (crlPath string, err error)
+	// UpdateCrtList applies the crt-list entries and returns the crt-list path, empty without entries
+	UpdateCrtList() string
 	// FrontCertsInuse returns true if a frontend certificate is configured.
 	FrontCertsInUse() bool
 	// Updated returns true if there is any updadted/created certificate
@@ -58,6 +70,9 @@ type Env struct {
 	BackendDir  string
 	CaDir       string
 	TCPCRDir    string
+	JWTDir      string
+	CrtListDir  string
+	CrlDir      string
 }
 
 var env Env
@@ -73,6 +88,7 @@ const (
 	BD_CERT
 	CA_CERT
 	TCP_CERT
+	CRTLIST_CERT
 )
 
 type SecretCtx struct {
@@ -100,7 +116,14 @@ func New(envParam Env) (Certificates, error) { //nolint:ireturn
 		backend:  make(map[string]*cert),
 		ca:       make(map[string]*cert),
 		TCPCR:    make(map[string]*cert),
+		CRTLIST:  make(map[string]*cert),
+		jwt:      make(map[string]*cert),
+		crl:      make(map[string]*cert),
 		mu:       &sync.Mutex{},
+		crtList: crtList{
+			entries: make(map[string]runtime.CrtListEntry),
+			current: make(map[string]runtime.CrtListEntry),
+		},
 	}, nil
 }
 
@@ -137,6 +160,10 @@ func (c *certs) AddSecret(secret *store.Secret, secretType SecretType) (certPath
 		certName = fmt.Sprintf("%s_%s", secret.Namespace, secret.Name)
 		certPath = path.Join(env.TCPCRDir, certName)
 		certs = c.TCPCR
+	case CRTLIST_CERT:
+		certName = fmt.Sprintf("%s_%s", secret.Namespace, secret.Name)
+		certPath = path.Join(env.CrtListDir, certName)
+		certs = c.CRTLIST
 	default:
 		return "", errors.New("unspecified context")
 	}
@@ -213,7 +240,8 @@ func (c *certs) updateRuntime(filename string, payload []byte, isCa bool) (bool,
 	updated = true
 	utils.GetLogger().Debugf("`commit ssl %s` ok [%s]", certType, filename)
 
-	if !alreadyExists && !isCa {
+	// Certificates of the crt-list dir are added with their entries
+	if !alreadyExists && !isCa && filepath.Dir(filename) != env.CrtListDir {
 		dirPath := filepath.Dir(filename)
 		err = c.client.CrtListEntryAdd(dirPath,
 			runtime.CrtListEntry{
@@ -267,12 +295,25 @@ func (c *certs) CleanCerts() {
 		c.TCPCR[i].inUse = false
 		c.TCPCR[i].updated = false
 	}
+	for i := range c.CRTLIST {
+		c.CRTLIST[i].inUse = false
+		c.CRTLIST[i].updated = false
+	}
+	for i := range c.jwt {
+		c.jwt[i].inUse = false
+	}
+	for i := range c.crl {
+		c.crl[i].inUse = false
+	}
+	c.crtList.entries = make(map[string]runtime.CrtListEntry)
 }
 
 func (c *certs) FrontCertsInUse() bool {
-	for _, cert := range c.frontend {
-		if cert.inUse {
-			return true
+	for _, certs := range []map[string]*cert{c.frontend, c.CRTLIST} {
+		for _, cert := range certs {
+			if cert.inUse {
+				return true
+			}
 		}
 	}
 	return false
@@ -284,10 +325,14 @@ func (c *certs) RefreshCerts(api api.HAProxyClient) {
 	c.refreshCerts(c.backend, env.BackendDir)
 	c.refreshCerts(c.ca, env.CaDir)
 	c.refreshCerts(c.TCPCR, env.TCPCRDir)
+	// Unused entries of the crt-list dir are removed with a reload
+	refreshReloadedFiles(c.CRTLIST, env.CrtListDir)
+	refreshReloadedFiles(c.jwt, env.JWTDir)
+	refreshReloadedFiles(c.crl, env.CrlDir)
 }
 
 func (c *certs) CertsUpdated() (reload bool) {
-	for _, certs := range []map[string]*cert{c.frontend, c.backend, c.ca, c.TCPCR} {
+	for _, certs := range []map[string]*cert{c.frontend, c.backend, c.ca, c.TCPCR, c.CRTLIST} {
 		for _, crt := range certs {
 			if crt.updated {
 				logger.Debugf("Secret '%s' was updated", crt.name)
@@ -0,0 +1,91 @@
+-- Copyright 2019 HAProxy Technologies LLC
+--
+-- Licensed under the Apache License, Version 2.0 (the "License");
+-- you may not use this file except in compliance with the License.
+-- You may obtain a copy of the License at
+--
+--    http://www.apache.org/licenses/LICENSE-2.0
+--
+-- Unless required by applicable law or agreed to in writing, software
+-- distributed under the License is distributed on an "AS IS" BASIS,
+-- WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+-- See the License for the specific language governing permissions and
+-- limitations under the License.
+
+-- auth-request sends a subrequest to an authentication service before the request is forwarded:
+--
+--   http-request lua.auth-request <url> <response headers>
+--
+-- The subrequest is a GET request to <url> carrying the headers of the request, its method and URI
+-- being sent in the X-Original-Method and X-Original-URI headers.
+-- The status code of the response is stored in txn.auth_response_code and txn.auth_response_successful
+-- is set when it is a 2xx, in which case the response headers listed in <response headers>, separated
+-- by commas or "-" for none, are copied to the request. They are removed from the request beforehand
+-- so that clients cannot set them.
+
+local skipped_headers = {
+    ["connection"] = true,
+    ["content-length"] = true,
+    ["expect"] = true,
+    ["host"] = true,
+    ["transfer-encoding"] = true,
+    ["upgrade"] = true,
+}
+
+-- header_values returns the values of a header table of HAProxy, indexed from 0, as a list.
+local function header_values(values)
+    local list = {}
+    local i = 0
+    if values[0] == nil then
+        i = 1
+    end
+    while values[i] ~= nil do
+        list[#list + 1] = values[i]
+        i = i + 1
+    end
+    return list
+end
+
+core.register_action("auth-request", { "http-req" }, function(txn, url, response_headers)
+    txn:set_var("txn.auth_response_successful", false)
+
+    local copied = {}
+    if response_headers ~= "-" then
+        for name in string.gmatch(response_headers, "[^,]+") do
+            copied[#copied + 1] = name
+            txn.http:req_del_header(name)
+        end
+    end
+
+    local headers = {}
+    for name, values in pairs(txn.http:req_get_headers()) do
+        if not skipped_headers[name] then
+            headers[name] = header_values(values)
+        end
+    end
+    headers["x-original-method"] = { txn.f:method() }
+    headers["x-original-uri"] = { txn.f:pathq() }
+    local host = txn.f:req_hdr("host")
+    if host ~= nil then
+        headers["x-forwarded-host"] = { host }
+    end
+
+    local response = core.httpclient():get({ url = url, headers = headers })
+    if response == nil or response.status == nil or response.status == 0 then
+        core.Warning("auth-request: no response from " .. url)
+        return
+    end
+    txn:set_var("txn.auth_response_code", response.status)
+    if response.status < 200 or response.status > 299 then
+        return
+    end
+    txn:set_var("txn.auth_response_successful", true)
+    for _, name in ipairs(copied) do
+        local values = response.headers[string.lower(name)]
+        if values ~= nil then
+            for _, value in ipairs(header_values(values)) do
+                txn.http:req_add_header(name, value)
+            end
+        end
+    end
+end, 2)
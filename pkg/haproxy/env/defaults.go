@@ -68,6 +68,18 @@ func SetGlobal(global *models.Global, logTargets *models.LogTargets, env Env) {
 		Type: "config",
 	}
 	global.LimitedQuic = true
+	if env.AuthLuaFile != "" {
+		if global.LuaOptions == nil {
+			global.LuaOptions = &models.LuaOptions{}
+		}
+		loaded := false
+		for _, load := range global.LuaOptions.Loads {
+			loaded = loaded || (load.File != nil && *load.File == env.AuthLuaFile)
+		}
+		if !loaded {
+			global.LuaOptions.Loads = append(global.LuaOptions.Loads, &models.LuaLoad{File: utils.Ptr(env.AuthLuaFile)})
+		}
+	}
 }
 
 // SetDefaults will set default values for Defaults section config.
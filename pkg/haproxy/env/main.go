@@ -15,6 +15,7 @@
 package env
 
 import (
+	_ "embed"
 	"errors"
 	"fmt"
 	"os"
@@ -26,6 +27,11 @@ import (
 	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
 )
 
+// authRequestLua is the Lua script of the lua.auth-request action used by forward authentication.
+//
+//go:embed auth-request.lua
+var authRequestLua []byte
+
 // Env contains Directories and files required by haproxy
 type Env struct {
 	Certs certs.Env
@@ -39,10 +45,12 @@ type Env struct {
 	StateDir       string
 	PatternDir     string
 	ErrFileDir     string
+	ErrorPagesDir  string
 	MapsDir        string
 	Binary         string
 	MainCFGFile    string
 	MainCFGRaw     []byte
+	AuthLuaFile    string
 	ControllerPort int
 }
 
@@ -82,15 +90,24 @@ func (env *Env) Init(osArgs utils.OSArgs) (err error) {
 	if err != nil {
 		return err
 	}
+	env.AuthLuaFile = filepath.Join(env.CfgDir, "auth-request.lua")
+	err = renameio.WriteFile(env.AuthLuaFile, authRequestLua, 0o644)
+	if err != nil {
+		return err
+	}
 	// Directories
 	env.Certs.MainDir = filepath.Join(env.CfgDir, "certs")
 	env.Certs.FrontendDir = filepath.Join(env.Certs.MainDir, "frontend")
 	env.Certs.BackendDir = filepath.Join(env.Certs.MainDir, "backend")
 	env.Certs.TCPCRDir = filepath.Join(env.Certs.MainDir, "tcp")
 	env.Certs.CaDir = filepath.Join(env.Certs.MainDir, "ca")
+	env.Certs.JWTDir = filepath.Join(env.Certs.MainDir, "jwt")
+	env.Certs.CrtListDir = filepath.Join(env.Certs.MainDir, "crt-list")
+	env.Certs.CrlDir = filepath.Join(env.Certs.MainDir, "crl")
 	env.MapsDir = filepath.Join(env.CfgDir, "maps")
 	env.PatternDir = filepath.Join(env.CfgDir, "patterns")
 	env.ErrFileDir = filepath.Join(env.CfgDir, "errorfiles")
+	env.ErrorPagesDir = filepath.Join(env.CfgDir, "errorpages")
 	env.ControllerPort = osArgs.ControllerPort
 	for _, d := range []string{
 		env.Certs.MainDir,
@@ -98,8 +115,12 @@ func (env *Env) Init(osArgs utils.OSArgs) (err error) {
 		env.Certs.BackendDir,
 		env.Certs.CaDir,
 		env.Certs.TCPCRDir,
+		env.Certs.JWTDir,
+		env.Certs.CrtListDir,
+		env.Certs.CrlDir,
 		env.MapsDir,
 		env.ErrFileDir,
+		env.ErrorPagesDir,
 		env.StateDir,
 		env.PatternDir,
 	} {
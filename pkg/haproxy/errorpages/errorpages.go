@@ -0,0 +1,57 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errorpages
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// errorPagesDir is the directory of the error pages files, one sub-directory per ConfigMap.
+var errorPagesDir string
+
+// codes are the status codes supported by http-error rules and errorloc directives.
+var codes = map[string]struct{}{
+	"200": {}, "400": {}, "401": {}, "403": {}, "404": {}, "405": {}, "407": {}, "408": {}, "410": {},
+	"413": {}, "425": {}, "429": {}, "500": {}, "501": {}, "502": {}, "503": {}, "504": {},
+}
+
+// SetDir sets the directory of the error pages files.
+func SetDir(dir string) {
+	errorPagesDir = dir
+}
+
+// GetDir returns the directory of the error pages files.
+func GetDir() string {
+	return errorPagesDir
+}
+
+// FileName returns the name of the error page file of a status code of a ConfigMap, relative to the error pages directory.
+func FileName(namespace, configMap, code string) string {
+	return filepath.Join(namespace+"_"+configMap, code)
+}
+
+// GetPath returns the path of the error page file of a status code of a ConfigMap.
+func GetPath(namespace, configMap, code string) string {
+	return filepath.Join(errorPagesDir, FileName(namespace, configMap, code))
+}
+
+// CheckCode returns an error if the status code is not supported by http-error rules.
+func CheckCode(code string) error {
+	if _, ok := codes[code]; !ok {
+		return fmt.Errorf("HTTP error code '%s' not supported", code)
+	}
+	return nil
+}
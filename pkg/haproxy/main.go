@@ -10,6 +10,7 @@ import (
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/api"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/certs"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/env"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/errorpages"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/maps"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/process"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/rules"
@@ -55,11 +56,14 @@ func New(osArgs utils.OSArgs, env env.Env, cfgFile []byte, p process.Process, cl
 		route.PATH_EXACT,
 		route.PATH_PREFIX_EXACT,
 		route.PATH_PREFIX,
+		route.PATH_REGEX,
+		route.MAINTENANCE,
 	}
 	if h.Maps, err = maps.New(env.MapsDir, persistentMaps); err != nil {
 		err = fmt.Errorf("failed to initialize haproxy maps: %w", err)
 		return h, err
 	}
+	errorpages.SetDir(env.ErrorPagesDir)
 	if p == nil {
 		h.Process = process.New(h.Env, osArgs, h.AuxCFGFile, h.HAProxyClient)
 	}
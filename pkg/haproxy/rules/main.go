@@ -32,6 +32,12 @@ type Rule interface {
 	GetType() Type
 }
 
+// PathScoped is implemented by the ingress rules applying to some of the ingress paths only,
+// the rule ID is then only added to the routes of these paths.
+type PathScoped interface {
+	ScopedPaths() []string
+}
+
 type List []Rule
 
 // RuleID uniquely identify a HAProxy Rule
@@ -174,3 +180,14 @@ func GetID(rule Rule) RuleID {
 	b = append(b, byte(rule.GetType()))
 	return RuleID(utils.Hash(b))
 }
+
+// createHTTPRequestRules inserts the http-request rules at the top of the frontend,
+// the last one first so that they are evaluated in order.
+func createHTTPRequestRules(client api.HAProxyClient, frontend, ingressACL string, httpRules ...models.HTTPRequestRule) error {
+	for i := len(httpRules) - 1; i >= 0; i-- {
+		if err := client.FrontendHTTPRequestRuleCreate(0, frontend, httpRules[i], ingressACL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
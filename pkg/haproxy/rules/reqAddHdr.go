@@ -0,0 +1,44 @@
+package rules
+
+import (
+	"errors"
+
+	"github.com/haproxytech/client-native/v6/models"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/api"
+)
+
+type ReqAddHdr struct {
+	HdrName   string
+	HdrFormat string
+	Response  bool
+}
+
+func (r ReqAddHdr) GetType() Type {
+	if r.Response {
+		return RES_ADD_HEADER
+	}
+	return REQ_ADD_HEADER
+}
+
+func (r ReqAddHdr) Create(client api.HAProxyClient, frontend *models.Frontend, ingressACL string) error {
+	if frontend.Mode == "tcp" {
+		return errors.New("HTTP headers cannot be added in TCP mode")
+	}
+	// RES_ADD_HEADER
+	if r.Response {
+		httpRule := models.HTTPResponseRule{
+			Type:      "add-header",
+			HdrName:   r.HdrName,
+			HdrFormat: r.HdrFormat,
+		}
+		return client.FrontendHTTPResponseRuleCreate(0, frontend.Name, httpRule, ingressACL)
+	}
+	// REQ_ADD_HEADER
+	httpRule := models.HTTPRequestRule{
+		Type:      "add-header",
+		HdrName:   r.HdrName,
+		HdrFormat: r.HdrFormat,
+	}
+	return client.FrontendHTTPRequestRuleCreate(0, frontend.Name, httpRule, ingressACL)
+}
@@ -0,0 +1,72 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/haproxytech/client-native/v6/models"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/api"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+// ReqClientCert denies the requests which did not go through the client certificate verification
+// configured for their hosts in the crt-list, like plain HTTP requests or requests sent on a TLS
+// connection established for another SNI.
+type ReqClientCert struct {
+	// SNIs are the hosts of the crt-list entries, wildcards included.
+	SNIs []string
+	// Required denies the requests without client certificate.
+	Required bool `json:",omitempty"`
+	// SSLRedirect lets the plain HTTP requests through, to be redirected to HTTPS by the
+	// ssl-redirect rule evaluated after this one.
+	SSLRedirect bool `json:",omitempty"`
+}
+
+func (r ReqClientCert) GetType() Type {
+	return REQ_CLIENT_CERT
+}
+
+func (r ReqClientCert) Create(client api.HAProxyClient, frontend *models.Frontend, ingressACL string) error {
+	if frontend.Mode == "tcp" {
+		return errors.New("client certificate verification cannot be configured in TCP mode")
+	}
+	var hosts, wildcards []string
+	for _, sni := range r.SNIs {
+		if strings.HasPrefix(sni, "*.") {
+			wildcards = append(wildcards, sni[1:])
+		} else {
+			hosts = append(hosts, sni)
+		}
+	}
+	var sslCond string
+	var sniConds []string
+	if r.SSLRedirect {
+		sslCond = "{ ssl_fc } "
+		sniConds = append(sniConds, strings.TrimSpace(sslCond))
+	}
+	if len(hosts) != 0 {
+		sniConds = append(sniConds, fmt.Sprintf("!{ ssl_fc_sni -m str -i %s }", strings.Join(hosts, " ")))
+	}
+	if len(wildcards) != 0 {
+		sniConds = append(sniConds, fmt.Sprintf("!{ ssl_fc_sni -m end -i %s }", strings.Join(wildcards, " ")))
+	}
+	httpRules := []models.HTTPRequestRule{
+		{
+			Type:       "deny",
+			DenyStatus: utils.PtrInt64(421),
+			Cond:       "if",
+			CondTest:   strings.Join(sniConds, " "),
+		},
+	}
+	if r.Required {
+		httpRules = append(httpRules, models.HTTPRequestRule{
+			Type:       "deny",
+			DenyStatus: utils.PtrInt64(403),
+			Cond:       "if",
+			CondTest:   sslCond + "!{ ssl_c_used }",
+		})
+	}
+	return createHTTPRequestRules(client, frontend.Name, ingressACL, httpRules...)
+}
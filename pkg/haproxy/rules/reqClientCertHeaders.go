@@ -0,0 +1,96 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/haproxytech/client-native/v6/models"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/api"
+)
+
+// ClientCertFields are the client certificate fields which can be forwarded in request headers,
+// with their default header name and their log-format value.
+var ClientCertFields = map[string]struct {
+	Header string
+	Format string
+}{
+	"dn":         {Header: "X-SSL-Client-DN", Format: "%[ssl_c_s_dn]"},
+	"cn":         {Header: "X-SSL-Client-CN", Format: "%[ssl_c_s_dn(cn)]"},
+	"issuer":     {Header: "X-SSL-Client-Issuer-DN", Format: "%[ssl_c_i_dn]"},
+	"sha1":       {Header: "X-SSL-Client-SHA1", Format: "%[ssl_c_sha1,hex]"},
+	"serial":     {Header: "X-SSL-Client-Serial", Format: "%[ssl_c_serial,hex]"},
+	"not-before": {Header: "X-SSL-Client-Not-Before", Format: "%[ssl_c_notbefore]"},
+	"not-after":  {Header: "X-SSL-Client-Not-After", Format: "%[ssl_c_notafter]"},
+	"cert":       {Header: "X-SSL-Client-Cert", Format: "%[ssl_c_der,base64]"},
+	// verify is set to SUCCESS, NONE without certificate or FAILED:<verify error code>
+	"verify": {Header: "X-SSL-Client-Verify"},
+}
+
+// ClientCertHeader is a request header carrying a field of the client certificate.
+type ClientCertHeader struct {
+	Field  string
+	Header string
+}
+
+// ReqClientCertHeaders forwards fields of the client certificate in request headers, the headers sent
+// by clients being removed so that they cannot be spoofed.
+type ReqClientCertHeaders struct {
+	Headers []ClientCertHeader
+}
+
+func (r ReqClientCertHeaders) GetType() Type {
+	return REQ_CLIENT_CERT_HDR
+}
+
+func (r ReqClientCertHeaders) Create(client api.HAProxyClient, frontend *models.Frontend, ingressACL string) error {
+	if frontend.Mode == "tcp" {
+		return errors.New("client certificate headers cannot be set in TCP mode")
+	}
+	httpRules := make([]models.HTTPRequestRule, 0, 2*len(r.Headers))
+	for _, header := range r.Headers {
+		httpRules = append(httpRules, models.HTTPRequestRule{
+			Type:    "del-header",
+			HdrName: header.Header,
+		})
+	}
+	for _, header := range r.Headers {
+		if header.Field == "verify" {
+			httpRules = append(httpRules,
+				models.HTTPRequestRule{
+					Type:      "set-header",
+					HdrName:   header.Header,
+					HdrFormat: "NONE",
+					Cond:      "if",
+					CondTest:  "!{ ssl_c_used }",
+				},
+				models.HTTPRequestRule{
+					Type:      "set-header",
+					HdrName:   header.Header,
+					HdrFormat: "SUCCESS",
+					Cond:      "if",
+					CondTest:  "{ ssl_c_used } { ssl_c_verify 0 }",
+				},
+				models.HTTPRequestRule{
+					Type:      "set-header",
+					HdrName:   header.Header,
+					HdrFormat: "FAILED:%[ssl_c_verify]",
+					Cond:      "if",
+					CondTest:  "{ ssl_c_used } !{ ssl_c_verify 0 }",
+				})
+			continue
+		}
+		field, ok := ClientCertFields[header.Field]
+		if !ok {
+			return fmt.Errorf("unknown client certificate field '%s'", header.Field)
+		}
+		httpRules = append(httpRules, models.HTTPRequestRule{
+			Type:      "set-header",
+			HdrName:   header.Header,
+			HdrFormat: field.Format,
+			Cond:      "if",
+			CondTest:  "{ ssl_c_used }",
+		})
+	}
+	return createHTTPRequestRules(client, frontend.Name, ingressACL, httpRules...)
+}
@@ -0,0 +1,28 @@
+package rules
+
+import (
+	"errors"
+
+	"github.com/haproxytech/client-native/v6/models"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/api"
+)
+
+type ReqDelHdr struct {
+	HdrName string
+}
+
+func (r ReqDelHdr) GetType() Type {
+	return REQ_DEL_HEADER
+}
+
+func (r ReqDelHdr) Create(client api.HAProxyClient, frontend *models.Frontend, ingressACL string) error {
+	if frontend.Mode == "tcp" {
+		return errors.New("HTTP headers cannot be deleted in TCP mode")
+	}
+	httpRule := models.HTTPRequestRule{
+		Type:    "del-header",
+		HdrName: r.HdrName,
+	}
+	return client.FrontendHTTPRequestRuleCreate(0, frontend.Name, httpRule, ingressACL)
+}
@@ -0,0 +1,72 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/haproxytech/client-native/v6/models"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/api"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+// ReqForwardAuth authenticates requests with a subrequest to an authentication service,
+// sent by the lua.auth-request action.
+type ReqForwardAuth struct {
+	URL string
+	// ResponseHeaders are copied from the response of the authentication service to the request.
+	ResponseHeaders []string `json:",omitempty"`
+	// SignIn is the URL where unauthenticated requests are redirected to, with their URL in the rd parameter.
+	SignIn string `json:",omitempty"`
+}
+
+func (r ReqForwardAuth) GetType() Type {
+	return REQ_AUTH
+}
+
+func (r ReqForwardAuth) Create(client api.HAProxyClient, frontend *models.Frontend, ingressACL string) error {
+	if frontend.Mode == "tcp" {
+		return errors.New("forward authentication cannot be configured in TCP mode")
+	}
+	responseHeaders := "-"
+	if len(r.ResponseHeaders) != 0 {
+		responseHeaders = strings.Join(r.ResponseHeaders, ",")
+	}
+	httpRules := []models.HTTPRequestRule{
+		{
+			Type:      "lua",
+			LuaAction: "auth-request",
+			LuaParams: fmt.Sprintf("%s %s", r.URL, responseHeaders),
+		},
+	}
+	if r.SignIn != "" {
+		separator := "?"
+		if strings.Contains(r.SignIn, "?") {
+			separator = "&"
+		}
+		httpRules = append(httpRules, models.HTTPRequestRule{
+			Type:       "redirect",
+			RedirType:  "location",
+			RedirValue: r.SignIn + separator + "rd=%[ssl_fc,iif(https,http)]://%[req.hdr(host)]%[pathq,url_enc]",
+			Cond:       "if",
+			CondTest:   "{ var(txn.auth_response_code) -m int 401 }",
+		})
+	}
+	for _, status := range []int64{401, 403} {
+		httpRules = append(httpRules, models.HTTPRequestRule{
+			Type:       "deny",
+			DenyStatus: utils.PtrInt64(status),
+			Cond:       "if",
+			CondTest:   fmt.Sprintf("{ var(txn.auth_response_code) -m int %d }", status),
+		})
+	}
+	// Any other failure, including an unreachable authentication service, is an internal error.
+	httpRules = append(httpRules, models.HTTPRequestRule{
+		Type:       "deny",
+		DenyStatus: utils.PtrInt64(500),
+		Cond:       "if",
+		CondTest:   "!{ var(txn.auth_response_successful) -m bool }",
+	})
+	return createHTTPRequestRules(client, frontend.Name, ingressACL, httpRules...)
+}
@@ -0,0 +1,113 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/haproxytech/client-native/v6/models"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/api"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+// JWTBearerVar holds the bearer token of the request, its claims can be read with the jwt_payload_query converter.
+const JWTBearerVar = "txn.jwt_bearer"
+
+// ReqJWTVerify denies with a 401 the requests without a valid bearer JWT: signed with one of the keys
+// and one of the algorithms, not expired and, when set, with the issuer and the audience.
+type ReqJWTVerify struct {
+	Keys         []JWTKey
+	Algorithms   []string
+	Issuer       string           `json:",omitempty"`
+	Audience     string           `json:",omitempty"`
+	ClaimHeaders []JWTClaimHeader `json:",omitempty"`
+}
+
+// JWTKey is the file of a public key, used for the tokens with its key ID when not empty.
+type JWTKey struct {
+	Kid  string `json:",omitempty"`
+	Path string
+}
+
+// JWTClaimHeader is a request header set to the value of a claim of valid tokens.
+type JWTClaimHeader struct {
+	Claim  string
+	Header string
+}
+
+func (r ReqJWTVerify) GetType() Type {
+	return REQ_AUTH
+}
+
+func (r ReqJWTVerify) Create(client api.HAProxyClient, frontend *models.Frontend, ingressACL string) error {
+	if frontend.Mode == "tcp" {
+		return errors.New("JWT validation cannot be configured in TCP mode")
+	}
+	deny := func(condTest string) models.HTTPRequestRule {
+		return models.HTTPRequestRule{
+			Type:       "deny",
+			DenyStatus: utils.PtrInt64(401),
+			Cond:       "if",
+			CondTest:   condTest,
+		}
+	}
+	setVar := func(name, expr, condTest string) models.HTTPRequestRule {
+		rule := models.HTTPRequestRule{
+			Type:     "set-var",
+			VarScope: "txn",
+			VarName:  name,
+			VarExpr:  expr,
+		}
+		if condTest != "" {
+			rule.Cond = "if"
+			rule.CondTest = condTest
+		}
+		return rule
+	}
+	httpRules := []models.HTTPRequestRule{
+		setVar("jwt_bearer", "http_auth_bearer", ""),
+		setVar("jwt_alg", fmt.Sprintf("var(%s),jwt_header_query('$.alg')", JWTBearerVar), ""),
+		deny(fmt.Sprintf("!{ var(txn.jwt_alg) -m str %s }", strings.Join(r.Algorithms, " "))),
+	}
+	for _, key := range r.Keys {
+		condTest := "!{ var(txn.jwt_verified) -m bool }"
+		if key.Kid != "" {
+			condTest += fmt.Sprintf(" { var(%s),jwt_header_query('$.kid') -m str %s }", JWTBearerVar, key.Kid)
+		}
+		condTest += fmt.Sprintf(" { var(%s),jwt_verify(txn.jwt_alg,\"%s\") -m int 1 }", JWTBearerVar, key.Path)
+		httpRules = append(httpRules, setVar("jwt_verified", "bool(1)", condTest))
+	}
+	httpRules = append(httpRules,
+		deny("!{ var(txn.jwt_verified) -m bool }"),
+		setVar("now", "date()", ""),
+		deny(fmt.Sprintf("!{ var(%s),jwt_payload_query('$.exp','int'),sub(txn.now) -m int gt 0 }", JWTBearerVar)),
+	)
+	if r.Issuer != "" {
+		httpRules = append(httpRules, deny(fmt.Sprintf("!{ var(%s),jwt_payload_query('$.iss') -m str %s }", JWTBearerVar, r.Issuer)))
+	}
+	if r.Audience != "" {
+		// The aud claim is a string, or an array of strings returned as JSON text by jwt_payload_query.
+		aud := fmt.Sprintf("var(%s),jwt_payload_query('$.aud')", JWTBearerVar)
+		httpRules = append(httpRules, deny(fmt.Sprintf(`!{ %s -m str %s } !{ %s -m reg '^\[(.*,)?[[:space:]]*"%s"[[:space:]]*(,.*)?\]$' }`,
+			aud, r.Audience, aud, regexp.QuoteMeta(r.Audience))))
+	}
+	for _, claimHeader := range r.ClaimHeaders {
+		claim := fmt.Sprintf("var(%s),jwt_payload_query('$.%s')", JWTBearerVar, claimHeader.Claim)
+		httpRules = append(httpRules,
+			models.HTTPRequestRule{
+				Type:    "del-header",
+				HdrName: claimHeader.Header,
+			},
+			models.HTTPRequestRule{
+				Type:      "set-header",
+				HdrName:   claimHeader.Header,
+				HdrFormat: "%[" + claim + "]",
+				Cond:      "if",
+				CondTest:  fmt.Sprintf("{ %s -m found }", claim),
+			},
+		)
+	}
+	return createHTTPRequestRules(client, frontend.Name, ingressACL, httpRules...)
+}
@@ -11,7 +11,9 @@ import (
 )
 
 type RequestRedirect struct {
-	Host         string
+	Host string
+	// URI is the log-format of the path and query of the location, the ones of the request when empty.
+	URI          string `json:",omitempty"`
 	RedirectCode int64
 	RedirectPort int
 	SSLRequest   bool
@@ -34,7 +36,11 @@ func (r RequestRedirect) Create(client api.HAProxyClient, frontend *models.Front
 		if r.SSLRequest {
 			scheme = "https"
 		}
-		rule = fmt.Sprintf(scheme+"://%s%%[capture.req.uri]", r.Host)
+		uri := r.URI
+		if uri == "" {
+			uri = "%[capture.req.uri]"
+		}
+		rule = fmt.Sprintf(scheme+"://%s%s", r.Host, uri)
 	}
 	httpRule := models.HTTPRequestRule{
 		Type:       "redirect",
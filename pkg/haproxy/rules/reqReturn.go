@@ -0,0 +1,62 @@
+package rules
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/haproxytech/client-native/v6/models"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/api"
+)
+
+// ReqReturn returns a static response to the request instead of forwarding it to a backend.
+type ReqReturn struct {
+	ContentType string `json:",omitempty"`
+	// Content is returned as is, it is not a log-format string.
+	Content string `json:",omitempty"`
+	// Headers values are log-format strings.
+	Headers    []ReturnHeader `json:",omitempty"`
+	StatusCode int64
+	CondTest   string `json:",omitempty"`
+}
+
+type ReturnHeader struct {
+	Name string
+	Fmt  string
+}
+
+func (r ReqReturn) GetType() Type {
+	return REQ_RETURN_STATUS
+}
+
+func (r ReqReturn) Create(client api.HAProxyClient, frontend *models.Frontend, ingressACL string) error {
+	if frontend.Mode == "tcp" {
+		return errors.New("HTTP response cannot be returned in TCP mode")
+	}
+	httpRule := models.HTTPRequestRule{
+		ReturnStatusCode: &r.StatusCode,
+		Type:             "return",
+	}
+	if r.CondTest != "" {
+		httpRule.Cond = "if"
+		httpRule.CondTest = r.CondTest
+	}
+	if r.Content != "" {
+		contentType := quoteString(r.ContentType)
+		httpRule.ReturnContentType = &contentType
+		httpRule.ReturnContentFormat = "string"
+		httpRule.ReturnContent = quoteString(r.Content)
+	}
+	for _, header := range r.Headers {
+		name := header.Name
+		format := quoteString(header.Fmt)
+		httpRule.ReturnHeaders = append(httpRule.ReturnHeaders, &models.ReturnHeader{Name: &name, Fmt: &format})
+	}
+	return client.FrontendHTTPRequestRuleCreate(0, frontend.Name, httpRule, ingressACL)
+}
+
+// quoteString double quotes the value so that it is written as a single word in HAProxy configuration,
+// escaping the characters interpreted inside double quotes.
+func quoteString(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, "\n", `\n`, "\r", `\r`).Replace(value) + `"`
+}
@@ -0,0 +1,30 @@
+package rules
+
+import (
+	"errors"
+
+	"github.com/haproxytech/client-native/v6/models"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/api"
+)
+
+// ReqSetHost rewrites the Host header of the requests, once the other request headers are modified.
+type ReqSetHost struct {
+	Host string
+}
+
+func (r ReqSetHost) GetType() Type {
+	return REQ_SET_HOST
+}
+
+func (r ReqSetHost) Create(client api.HAProxyClient, frontend *models.Frontend, ingressACL string) error {
+	if frontend.Mode == "tcp" {
+		return errors.New("HTTP host cannot be set in TCP mode")
+	}
+	httpRule := models.HTTPRequestRule{
+		Type:      "set-header",
+		HdrName:   "Host",
+		HdrFormat: r.Host,
+	}
+	return client.FrontendHTTPRequestRuleCreate(0, frontend.Name, httpRule, ingressACL)
+}
@@ -0,0 +1,75 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/haproxytech/client-native/v6/models"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/api"
+)
+
+// CustomRouteVar is set once the request matches a custom route, so that the following ones are ignored.
+const CustomRouteVar = "txn.custom_route"
+
+// ReqSetPathMatch sets the txn.path_match variable of the requests routed by custom routes,
+// so that the rules of the ingress of the route apply to them as for the routes of the maps.
+// Routes are evaluated in order, the first one matching the request is elected.
+type ReqSetPathMatch struct {
+	Routes []PathMatchRoute
+}
+
+// PathMatchRoute is a custom route of ReqSetPathMatch.
+type PathMatchRoute struct {
+	// Condition matched by the requests of the route.
+	Condition string
+	// PathMatch is the value of txn.path_match, it is left unchanged when empty.
+	PathMatch string
+	// AfterHostPath is set for the routes evaluated after the host/path routes of the maps.
+	AfterHostPath bool
+}
+
+func (r ReqSetPathMatch) GetType() Type {
+	return REQ_SET_PATH_MATCH
+}
+
+func (r ReqSetPathMatch) Create(client api.HAProxyClient, frontend *models.Frontend, ingressACL string) error {
+	if frontend.Mode == "tcp" {
+		return errors.New("path match of custom routes cannot be set in TCP mode")
+	}
+	httpRules := make([]models.HTTPRequestRule, 0, 2*len(r.Routes))
+	for _, route := range r.Routes {
+		condTest := fmt.Sprintf("%s !{ var(%s) -m found }", route.Condition, CustomRouteVar)
+		if route.AfterHostPath {
+			// Once set, txn.path_match excludes the following routes.
+			if route.PathMatch != "" {
+				httpRules = append(httpRules, pathMatchRule(route.PathMatch, fmt.Sprintf("%s !{ var(%s) -m found }", condTest, HTTPACLVar)))
+			}
+			continue
+		}
+		if route.PathMatch != "" {
+			httpRules = append(httpRules, pathMatchRule(route.PathMatch, condTest))
+		}
+		httpRules = append(httpRules, models.HTTPRequestRule{
+			Type:     "set-var",
+			VarName:  strings.TrimPrefix(CustomRouteVar, "txn."),
+			VarScope: "txn",
+			VarExpr:  "bool(true)",
+			Cond:     "if",
+			CondTest: condTest,
+		})
+	}
+	return createHTTPRequestRules(client, frontend.Name, ingressACL, httpRules...)
+}
+
+func pathMatchRule(pathMatch, condTest string) models.HTTPRequestRule {
+	return models.HTTPRequestRule{
+		Type:     "set-var",
+		VarName:  strings.TrimPrefix(HTTPACLVar, "txn."),
+		VarScope: "txn",
+		VarExpr:  fmt.Sprintf("str(%s)", pathMatch),
+		Cond:     "if",
+		CondTest: condTest,
+	}
+}
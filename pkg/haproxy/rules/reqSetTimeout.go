@@ -0,0 +1,62 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/haproxytech/client-native/v6/models"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/api"
+)
+
+// ReqSetTimeout sets the timeout of the requests matching the rule in a txn variable.
+// set-timeout server|tunnel is only allowed in backends, where the timeout is applied by SetTimeoutBackendRules.
+type ReqSetTimeout struct {
+	// TimeoutType is either "server" or "tunnel".
+	TimeoutType string
+	// Paths restricts the rule to these ingress paths, all of them when empty.
+	Paths []string
+	// Timeout in milliseconds.
+	Timeout int64
+}
+
+func (r ReqSetTimeout) GetType() Type {
+	return REQ_SET_TIMEOUT
+}
+
+func (r ReqSetTimeout) ScopedPaths() []string {
+	return r.Paths
+}
+
+func (r ReqSetTimeout) Create(client api.HAProxyClient, frontend *models.Frontend, ingressACL string) error {
+	if frontend.Mode == "tcp" {
+		return errors.New("set-timeout cannot be configured in TCP mode")
+	}
+	httpRule := models.HTTPRequestRule{
+		Type:     "set-var",
+		VarName:  setTimeoutVar(r.TimeoutType),
+		VarScope: "txn",
+		VarExpr:  fmt.Sprintf("int(%d)", r.Timeout),
+	}
+	return client.FrontendHTTPRequestRuleCreate(0, frontend.Name, httpRule, ingressACL)
+}
+
+// SetTimeoutBackendRules returns the backend rules applying the timeouts set by ReqSetTimeout rules.
+func SetTimeoutBackendRules() models.HTTPRequestRules {
+	httpRules := models.HTTPRequestRules{}
+	for _, timeoutType := range []string{models.HTTPRequestRuleTimeoutTypeServer, models.HTTPRequestRuleTimeoutTypeTunnel} {
+		variable := "txn." + setTimeoutVar(timeoutType)
+		httpRules = append(httpRules, &models.HTTPRequestRule{
+			Type:        "set-timeout",
+			TimeoutType: timeoutType,
+			Timeout:     "var(" + variable + ")",
+			Cond:        "if",
+			CondTest:    "{ var(" + variable + ") -m found }",
+		})
+	}
+	return httpRules
+}
+
+func setTimeoutVar(timeoutType string) string {
+	return "set_timeout_" + timeoutType
+}
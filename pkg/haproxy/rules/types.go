@@ -10,18 +10,25 @@ const (
 	REQ_INSPECT_DELAY
 	REQ_PROXY_PROTOCOL
 	REQ_SET_VAR
+	REQ_SET_PATH_MATCH
 	REQ_SET_SRC
 	REQ_DENY
 	REQ_TRACK
+	REQ_CLIENT_CERT
+	REQ_CLIENT_CERT_HDR
 	REQ_AUTH
 	REQ_RATELIMIT
 	REQ_CAPTURE
 	REQ_REDIRECT
 	REQ_FORWARDED_PROTO
 	REQ_SET_HEADER
+	REQ_ADD_HEADER
+	REQ_DEL_HEADER
 	REQ_SET_HOST
 	REQ_PATH_REWRITE
+	REQ_SET_TIMEOUT
 	REQ_RETURN_STATUS
+	RES_ADD_HEADER
 	RES_SET_HEADER
 )
 
@@ -30,17 +37,24 @@ var constLookup = map[Type]string{
 	REQ_INSPECT_DELAY:   "REQ_INSPECT_DELAY",
 	REQ_PROXY_PROTOCOL:  "REQ_PROXY_PROTOCOL",
 	REQ_SET_VAR:         "REQ_SET_VAR",
+	REQ_SET_PATH_MATCH:  "REQ_SET_PATH_MATCH",
 	REQ_SET_SRC:         "REQ_SET_SRC",
 	REQ_DENY:            "REQ_DENY",
 	REQ_TRACK:           "REQ_TRACK",
+	REQ_CLIENT_CERT:     "REQ_CLIENT_CERT",
+	REQ_CLIENT_CERT_HDR: "REQ_CLIENT_CERT_HDR",
 	REQ_AUTH:            "REQ_AUTH",
 	REQ_RATELIMIT:       "REQ_RATELIMIT",
 	REQ_CAPTURE:         "REQ_CAPTURE",
 	REQ_REDIRECT:        "REQ_REDIRECT",
 	REQ_FORWARDED_PROTO: "REQ_FORWARDED_PROTO",
 	REQ_SET_HEADER:      "REQ_SET_HEADER",
+	REQ_ADD_HEADER:      "REQ_ADD_HEADER",
+	REQ_DEL_HEADER:      "REQ_DEL_HEADER",
 	REQ_SET_HOST:        "REQ_SET_HOST",
 	REQ_PATH_REWRITE:    "REQ_PATH_REWRITE",
+	REQ_SET_TIMEOUT:     "REQ_SET_TIMEOUT",
+	RES_ADD_HEADER:      "RES_ADD_HEADER",
 	RES_SET_HEADER:      "RES_SET_HEADER",
 	REQ_RETURN_STATUS:   "REQ_RETURN_STATUS",
 }
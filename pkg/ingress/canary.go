@@ -0,0 +1,133 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingress
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/rules"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+)
+
+// httpTokenRegex matches valid header and cookie names.
+var httpTokenRegex = regexp.MustCompile("^[A-Za-z0-9!#$%&'*+.^_`|~-]+$")
+
+var errCanarySSLPassthrough = errors.New("canary routing is not supported with ssl-passthrough")
+
+// canaryRandRule draws the random number of the canary weights once per request,
+// so that the backend switching rules and the path match of the custom routes elect the same route.
+var canaryRandRule = rules.ReqSetVar{
+	Name:       "canary_rand",
+	Scope:      "txn",
+	Expression: "rand(100)",
+}
+
+// canary holds the criteria routing the requests of the paths of a canary ingress to its services
+// instead of the services of the ingress having the same host and path.
+type canary struct {
+	header      string
+	headerValue string
+	cookie      string
+	weight      int
+}
+
+// newCanary returns the canary criteria of the ingress, nil when the ingress is not a canary one.
+func newCanary(ingressAnnotations map[string]string) (*canary, error) {
+	enabled, err := annotations.Bool("canary", ingressAnnotations)
+	if err != nil || !enabled {
+		return nil, err
+	}
+	c := &canary{
+		header:      annotations.String("canary-by-header", ingressAnnotations),
+		headerValue: annotations.String("canary-by-header-value", ingressAnnotations),
+		cookie:      annotations.String("canary-by-cookie", ingressAnnotations),
+	}
+	if c.header != "" && !httpTokenRegex.MatchString(c.header) {
+		return nil, fmt.Errorf("canary-by-header: invalid header name '%s'", c.header)
+	}
+	if c.headerValue != "" {
+		if c.header == "" {
+			return nil, errors.New("canary-by-header-value requires canary-by-header")
+		}
+		if strings.ContainsAny(c.headerValue, " \t\"'\\#{}") {
+			return nil, fmt.Errorf("canary-by-header-value: unsupported characters in '%s'", c.headerValue)
+		}
+	}
+	if c.cookie != "" && !httpTokenRegex.MatchString(c.cookie) {
+		return nil, fmt.Errorf("canary-by-cookie: invalid cookie name '%s'", c.cookie)
+	}
+	if weight := annotations.String("canary-weight", ingressAnnotations); weight != "" {
+		c.weight, err = strconv.Atoi(weight)
+		if err != nil || c.weight < 0 || c.weight > 100 {
+			return nil, fmt.Errorf("canary-weight: '%s' is not an integer between 0 and 100", weight)
+		}
+	}
+	if c.header == "" && c.cookie == "" && c.weight == 0 {
+		return nil, errors.New("canary ingress requires canary-by-header, canary-by-cookie or canary-weight")
+	}
+	return c, nil
+}
+
+// CanaryError returns why the canary annotations of the ingress are invalid,
+// nil when they are valid or when the ingress is not a canary one.
+func CanaryError(ing *store.Ingress, configMapAnnotations map[string]string) error {
+	c, err := newCanary(ing.Annotations)
+	if err != nil || c == nil {
+		return err
+	}
+	if sslPassthrough, _ := annotations.Bool("ssl-passthrough", ing.Annotations, configMapAnnotations); sslPassthrough {
+		return errCanarySSLPassthrough
+	}
+	return nil
+}
+
+// conditions returns the conditions of the requests routed to the canary services.
+// Without value, the header routes requests when set to "always"; header and cookie set to "never" opt out of the weight.
+func (c canary) conditions() []string {
+	conditions := []string{}
+	optOut := ""
+	if c.header != "" {
+		value := c.headerValue
+		if value == "" {
+			value = "always"
+			optOut += fmt.Sprintf("!{ req.hdr(%s) -m str never } ", c.header)
+		}
+		conditions = append(conditions, fmt.Sprintf("{ req.hdr(%s) -m str %s }", c.header, value))
+	}
+	if c.cookie != "" {
+		conditions = append(conditions, fmt.Sprintf("{ req.cook(%s) -m str always }", c.cookie))
+		optOut += fmt.Sprintf("!{ req.cook(%s) -m str never } ", c.cookie)
+	}
+	if c.weight > 0 {
+		conditions = append(conditions, fmt.Sprintf("%s{ var(txn.%s) -m int lt %d }", optOut, canaryRandRule.Name, c.weight))
+	}
+	return conditions
+}
+
+// addRules adds the rules the conditions of the canary depend on to the main frontends.
+func (c canary) addRules(h haproxy.HAProxy) {
+	if c.weight == 0 {
+		return
+	}
+	for _, frontend := range []string{h.FrontHTTP, h.FrontHTTPS} {
+		logger.Error(h.AddRule(frontend, canaryRandRule, false))
+	}
+}
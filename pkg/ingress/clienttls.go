@@ -0,0 +1,84 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingress
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/certs"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/rules"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+)
+
+// handleClientTLS adds the TLS certificates of the ingress to the crt-list of the HTTPS frontend with the
+// client certificate verification of the client-ca annotation of the ingress, and returns the rule denying
+// the requests which did not go through this verification.
+// It returns a nil rule without client-ca annotation, the certificates being served from the frontend
+// certificates directory.
+func (i *Ingress) handleClientTLS(k store.K8s, h haproxy.HAProxy) (*rules.ReqClientCert, error) {
+	// Only the annotations of the ingress are used, client-ca in the ConfigMap applies to the whole HTTPS frontend.
+	caSecret, err := annotations.Secret("client-ca", i.resource.Namespace, k, i.resource.Annotations)
+	if err != nil || caSecret == nil {
+		return nil, err
+	}
+	if i.sslPassthrough {
+		return nil, errors.New("client-ca is not supported with ssl-passthrough")
+	}
+	if len(i.resource.TLS) == 0 {
+		return nil, errors.New("client-ca requires TLS hosts")
+	}
+	entry := certs.CrtListEntry{Verify: "required"}
+	optional, err := annotations.Bool("client-crt-optional", i.resource.Annotations)
+	if err != nil {
+		return nil, err
+	}
+	if optional {
+		entry.Verify = "optional"
+	}
+	if entry.CAFile, err = h.AddSecret(caSecret, certs.CA_CERT); err != nil {
+		return nil, fmt.Errorf("client-ca: %w", err)
+	}
+	crlSecret, err := annotations.Secret("client-crl", i.resource.Namespace, k, i.resource.Annotations)
+	if err != nil {
+		return nil, err
+	}
+	if crlSecret != nil {
+		if entry.CRLFile, err = h.AddCRL(crlSecret); err != nil {
+			return nil, fmt.Errorf("client-crl: %w", err)
+		}
+	}
+
+	rule := &rules.ReqClientCert{Required: !optional}
+	for _, tls := range i.resource.TLS {
+		if tls.SecretName == "" {
+			return nil, fmt.Errorf("no TLS secret for host '%s'", tls.Host)
+		}
+		secret, errSecret := k.GetSecret(i.resource.Namespace, tls.SecretName)
+		if errSecret != nil {
+			return nil, errSecret
+		}
+		entry.SNIFilter = []string{tls.Host}
+		if err = h.AddCrtListEntry(secret, entry); err != nil {
+			return nil, err
+		}
+		rule.SNIs = append(rule.SNIs, tls.Host)
+	}
+	sort.Strings(rule.SNIs)
+	return rule, nil
+}
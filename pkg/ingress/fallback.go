@@ -0,0 +1,75 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingress
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy"
+	"github.com/haproxytech/kubernetes-ingress/pkg/route"
+	"github.com/haproxytech/kubernetes-ingress/pkg/service"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+)
+
+// handleFallbackService routes the requests of the backend of the service to the service of the fallback-service annotation
+// when the backend has no usable server.
+func (i *Ingress) handleFallbackService(k store.K8s, h haproxy.HAProxy, svc *service.Service, backendName string, a annotations.Annotations) error {
+	value := a.String("fallback-service", svc.GetResource().Annotations, i.resource.Annotations)
+	if value == "" {
+		return nil
+	}
+	if i.sslPassthrough {
+		return errors.New("not supported with ssl-passthrough")
+	}
+	svcPath, err := servicePortPath(svc.GetResource().Namespace, value)
+	if err != nil {
+		return err
+	}
+	fallbackSvc, fallbackBackendName, err := i.handleService(k, h, svcPath, a)
+	if err != nil {
+		return err
+	}
+	if fallbackBackendName == backendName {
+		return errors.New("the fallback service is the service itself")
+	}
+	if err = route.AddFallbackRoute(backendName, fallbackBackendName); err != nil {
+		return err
+	}
+	handleEndpoints(k, h, fallbackSvc, fallbackBackendName)
+	return nil
+}
+
+// servicePortPath returns the path of the service of the namespace designated by "<service>:<port>",
+// the port being a port name or number.
+func servicePortPath(namespace, value string) (*store.IngressPath, error) {
+	name, port, found := strings.Cut(value, ":")
+	if !found || name == "" || port == "" {
+		return nil, fmt.Errorf("invalid value '%s', expected '<service>:<port>'", value)
+	}
+	svcPath := &store.IngressPath{
+		SvcNamespace: namespace,
+		SvcName:      name,
+	}
+	if number, err := strconv.ParseInt(port, 10, 64); err == nil {
+		svcPath.SvcPortInt = number
+	} else {
+		svcPath.SvcPortString = port
+	}
+	return svcPath, nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingress
+
+import (
+	"errors"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy"
+	"github.com/haproxytech/kubernetes-ingress/pkg/route"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+)
+
+// handleHostDefaultBackend routes the requests of the hosts of the ingress rules matching no route
+// to the service of the host-default-backend annotation, instead of the default backend of the controller.
+func (i *Ingress) handleHostDefaultBackend(k store.K8s, h haproxy.HAProxy, a annotations.Annotations) error {
+	value := annotations.String("host-default-backend", i.resource.Annotations)
+	if value == "" {
+		return nil
+	}
+	if i.sslPassthrough {
+		return errors.New("not supported with ssl-passthrough")
+	}
+	svcPath, err := servicePortPath(i.resource.Namespace, value)
+	if err != nil {
+		return err
+	}
+	hosts := []string{}
+	for _, rule := range i.resource.Rules {
+		if rule.Host != "" {
+			hosts = append(hosts, rule.Host)
+		}
+	}
+	if len(hosts) == 0 {
+		return errors.New("no ingress rule with a host")
+	}
+	svc, backendName, err := i.handleService(k, h, svcPath, a)
+	if err != nil {
+		return err
+	}
+	owner := i.resource.Namespace + "/" + i.resource.Name
+	for _, host := range hosts {
+		hostRoute := route.Route{
+			Host:        host,
+			Path:        svcPath,
+			BackendName: backendName,
+		}
+		// Conflicts are reported by the controller with an event on the ingress.
+		if err := route.AddHostDefaultRoute(hostRoute, owner); err != nil {
+			logger.Debugf("Ingress '%s': host-default-backend: %s", owner, err)
+		}
+	}
+	handleEndpoints(k, h, svc, backendName)
+	return nil
+}
@@ -15,6 +15,11 @@
 package ingress
 
 import (
+	"errors"
+	"fmt"
+	"slices"
+
+	v3 "github.com/haproxytech/kubernetes-ingress/crs/api/ingress/v3"
 	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/certs"
@@ -26,11 +31,25 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 )
 
+// Kinds of the custom resources supported as backend of ingress paths.
+//
+//nolint:golint,stylecheck
+const (
+	STATIC_RESPONSE_CR_KIND = "StaticResponse"
+	TRAFFIC_SPLIT_CR_KIND   = "TrafficSplit"
+)
+
 type Ingress struct {
 	annotations     annotations.Annotations
 	resource        *store.Ingress
+	canary          *canary
+	routeMatch      *routeMatch
+	clientCert      *rules.ReqClientCert
 	controllerClass string
 	ruleIDs         []rules.RuleID
+	// scopedRuleIDs are the paths of the rules applying to some of the ingress paths only.
+	scopedRuleIDs   map[rules.RuleID][]string
+	pathMatch       string
 	allowEmptyClass bool
 	sslPassthrough  bool
 }
@@ -65,14 +84,98 @@ func (i Ingress) Supported(k8s store.K8s, a annotations.Annotations) (supported
 }
 
 func (i *Ingress) handlePath(k store.K8s, h haproxy.HAProxy, host string, path *store.IngressPath, a annotations.Annotations) (err error) {
-	svc, err := service.New(k, path, h.Certificates, i.sslPassthrough, i.resource, i.resource.Annotations, k.ConfigMaps.Main.Annotations)
+	if path.Resource != nil {
+		return i.handleResourcePath(k, h, host, path, a)
+	}
+	svc, backendName, err := i.handleService(k, h, path, a)
 	if err != nil {
 		return err
 	}
+	// Route
+	ingRoute := route.Route{
+		Host:           host,
+		Path:           path,
+		HAProxyRules:   i.pathRuleIDs(path),
+		BackendName:    backendName,
+		SSLPassthrough: i.sslPassthrough,
+		PathMatch:      i.pathMatch,
+		Priority:       i.routePriority(svc.GetResource().Annotations),
+	}
+
+	routeACLAnn := a.String("route-acl", svc.GetResource().Annotations)
+	matchCond := ""
+	if i.routeMatch != nil {
+		route.AddFrontendACLs(i.routeMatch.acls)
+		matchCond = i.routeMatch.condition() + " "
+	}
+	switch {
+	case i.canary != nil:
+		i.canary.addRules(h)
+		conditions := i.canary.conditions()
+		for j := range conditions {
+			conditions[j] = matchCond + conditions[j]
+		}
+		route.AddConditionalRoute(ingRoute, conditions)
+	case i.routeMatch != nil && routeACLAnn != "":
+		route.AddConditionalRoute(ingRoute, []string{fmt.Sprintf("%s{ %s }", matchCond, routeACLAnn)})
+	case i.routeMatch != nil:
+		route.AddConditionalRoute(ingRoute, []string{i.routeMatch.condition()})
+	case routeACLAnn == "":
+		err = route.AddHostPathRoute(ingRoute, h.Maps)
+		if err != nil {
+			return err
+		}
+	default:
+		route.AddCustomRoute(ingRoute, routeACLAnn)
+	}
+	if errFallback := i.handleFallbackService(k, h, svc, backendName, a); errFallback != nil {
+		logger.Errorf("Ingress '%s/%s': fallback-service: %s", i.resource.Namespace, i.resource.Name, errFallback)
+	}
+	handleEndpoints(k, h, svc, backendName)
+	return err
+}
+
+// handleResourcePath routes the requests of a path whose backend is a custom resource.
+func (i *Ingress) handleResourcePath(k store.K8s, h haproxy.HAProxy, host string, path *store.IngressPath, a annotations.Annotations) error {
+	resource := path.Resource
+	if resource.APIGroup != v3.GroupName || (resource.Kind != STATIC_RESPONSE_CR_KIND && resource.Kind != TRAFFIC_SPLIT_CR_KIND) {
+		return fmt.Errorf("backend resource %s '%s' of group '%s' is not supported, only %s and %s from '%s' are",
+			resource.Kind, resource.Name, resource.APIGroup, STATIC_RESPONSE_CR_KIND, TRAFFIC_SPLIT_CR_KIND, v3.GroupName)
+	}
+	if i.canary != nil {
+		return fmt.Errorf("backend resource %s '%s' cannot be used in a canary ingress", resource.Kind, resource.Name)
+	}
+	if i.routeMatch != nil {
+		return fmt.Errorf("backend resource %s '%s' cannot be used with route-match annotations", resource.Kind, resource.Name)
+	}
+	if i.sslPassthrough {
+		return fmt.Errorf("backend resource %s '%s' cannot be served with ssl-passthrough", resource.Kind, resource.Name)
+	}
+	if resource.Kind == TRAFFIC_SPLIT_CR_KIND {
+		return i.handleTrafficSplitPath(k, h, host, path, a)
+	}
+	return i.handleStaticResponsePath(k, h, host, path)
+}
+
+// routePriority returns the priority of the custom routes of the ingress paths, 0 when invalid.
+func (i *Ingress) routePriority(serviceAnnotations map[string]string) int64 {
+	priority, err := annotations.Int("route-priority", serviceAnnotations, i.resource.Annotations)
+	if err != nil {
+		logger.Errorf("Ingress '%s/%s': %s, using 0", i.resource.Namespace, i.resource.Name, err)
+	}
+	return int64(priority)
+}
+
+// handleService creates the backend of the service of the path and returns the service with the backend name.
+func (i *Ingress) handleService(k store.K8s, h haproxy.HAProxy, path *store.IngressPath, a annotations.Annotations) (*service.Service, string, error) {
+	svc, err := service.New(k, path, h.Certificates, i.sslPassthrough, i.resource, i.resource.Annotations, k.ConfigMaps.Main.Annotations)
+	if err != nil {
+		return nil, "", err
+	}
 	// Backend
 	err = svc.HandleBackend(k, h, a)
 	if err != nil {
-		return err
+		return nil, "", err
 	}
 	backendName, _ := svc.GetBackendName()
 	// If we've got a standalone ingress, put an adhoc RuntimeBackend in HAProxyRuntimeStandalone
@@ -93,30 +196,15 @@ func (i *Ingress) handlePath(k store.K8s, h haproxy.HAProxy, host string, path *
 			runtimeBackends[backendName] = &store.RuntimeBackend{Name: backendName}
 		}
 	}
-	// Route
-	ingRoute := route.Route{
-		Host:           host,
-		Path:           path,
-		HAProxyRules:   i.ruleIDs,
-		BackendName:    backendName,
-		SSLPassthrough: i.sslPassthrough,
-	}
+	return svc, backendName, nil
+}
 
-	routeACLAnn := a.String("route-acl", svc.GetResource().Annotations)
-	if routeACLAnn == "" {
-		err = route.AddHostPathRoute(ingRoute, h.Maps)
-	} else {
-		err = route.AddCustomRoute(ingRoute, routeACLAnn, h)
-	}
-	if err != nil {
-		return err
-	}
-	// Endpoints
+// handleEndpoints creates the servers of the backend of the service, once per sync.
+func handleEndpoints(k store.K8s, h haproxy.HAProxy, svc *service.Service, backendName string) {
 	if _, ok := k.BackendsProcessed[backendName]; !ok {
 		svc.HandleHAProxySrvs(k, h)
 		k.BackendsProcessed[backendName] = struct{}{}
 	}
-	return err
 }
 
 // HandleAnnotations processes ingress annotations to create HAProxy Rules and constructs
@@ -126,20 +214,50 @@ func (i *Ingress) handlePath(k store.K8s, h haproxy.HAProxy, host string, path *
 func (i *Ingress) handleAnnotations(k store.K8s, h haproxy.HAProxy) {
 	var err error
 	result := rules.List{}
-	for _, a := range i.annotations.Frontend(i.resource, &result, h.Maps) {
+	for _, a := range i.annotations.Frontend(i.resource, &result, h.Maps, h.Certificates) {
 		err = a.Process(k, i.resource.Annotations, k.ConfigMaps.Main.Annotations)
 		if err != nil {
 			logger.Errorf("Ingress '%s/%s': annotation %s: %s", i.resource.Namespace, i.resource.Name, a.GetName(), err)
 		}
 	}
+	if i.clientCert != nil {
+		// The client certificate is checked before the redirections, plain HTTP requests
+		// redirected to HTTPS are checked once redirected.
+		for _, rule := range result {
+			if redirect, ok := rule.(*rules.RequestRedirect); ok && redirect.SSLRedirect {
+				i.clientCert.SSLRedirect = true
+			}
+		}
+		result.Add(i.clientCert)
+	}
 	i.ruleIDs = addRules(result, h, true)
+	i.scopedRuleIDs = map[rules.RuleID][]string{}
+	for _, rule := range result {
+		if scoped, ok := rule.(rules.PathScoped); ok && len(scoped.ScopedPaths()) != 0 {
+			i.scopedRuleIDs[rules.GetID(rule)] = scoped.ScopedPaths()
+		}
+	}
+}
+
+// pathRuleIDs returns the IDs of the ingress rules applying to the path.
+func (i *Ingress) pathRuleIDs(path *store.IngressPath) []rules.RuleID {
+	if len(i.scopedRuleIDs) == 0 {
+		return i.ruleIDs
+	}
+	ruleIDs := make([]rules.RuleID, 0, len(i.ruleIDs))
+	for _, id := range i.ruleIDs {
+		if paths, scoped := i.scopedRuleIDs[id]; !scoped || slices.Contains(paths, path.Path) {
+			ruleIDs = append(ruleIDs, id)
+		}
+	}
+	return ruleIDs
 }
 
 func HandleCfgMapAnnotations(k store.K8s, h haproxy.HAProxy, a annotations.Annotations) {
 	var err error
 	result := rules.List{}
 	logger.Tracef("Processing Ingress annotations in ConfigMap")
-	for _, a := range a.Frontend(nil, &result, h.Maps) {
+	for _, a := range a.Frontend(nil, &result, h.Maps, h.Certificates) {
 		err = a.Process(k, k.ConfigMaps.Main.Annotations)
 		if err != nil {
 			logger.Errorf("ConfigMap: annotation %s: %s", a.GetName(), err)
@@ -185,7 +303,9 @@ func addRules(list rules.List, h haproxy.HAProxy, ingressRule bool) []rules.Rule
 // by creating corresponding backend, route and HTTP rules.
 func (i *Ingress) Update(k store.K8s, h haproxy.HAProxy, a annotations.Annotations) {
 	// Default Backend
-	if i.resource.DefaultBackend != nil {
+	if i.resource.DefaultBackend != nil && i.resource.DefaultBackend.Resource != nil {
+		logger.Errorf("Ingress '%s/%s': default backend: resource backends are only supported in ingress rules", i.resource.Namespace, i.resource.Name)
+	} else if i.resource.DefaultBackend != nil {
 		svc, err := service.New(k, i.resource.DefaultBackend, h.Certificates, false, i.resource, i.resource.Annotations, k.ConfigMaps.Main.Annotations)
 		if svc != nil {
 			err = svc.SetDefaultBackend(k, h, []string{h.FrontHTTP, h.FrontHTTPS}, a)
@@ -197,11 +317,23 @@ func (i *Ingress) Update(k store.K8s, h haproxy.HAProxy, a annotations.Annotatio
 			logger.Infof("Setting http default backend to '%s'", backendName)
 		}
 	}
+	enabled, err := annotations.Bool("ssl-passthrough", i.resource.Annotations, k.ConfigMaps.Main.Annotations)
+	if err != nil {
+		logger.Errorf("Ingress '%s/%s': SSL Passthrough parsing: %s", i.resource.Namespace, i.resource.Name, err)
+	} else if enabled {
+		i.sslPassthrough = true
+	}
 	// Ingress secrets
 	logger.Tracef("Ingress '%s/%s': processing secrets...", i.resource.Namespace, i.resource.Name)
+	i.clientCert, err = i.handleClientTLS(k, h)
+	if err != nil {
+		logger.Errorf("Ingress '%s/%s': client TLS auth: %s, ingress rules ignored", i.resource.Namespace, i.resource.Name, err)
+		return
+	}
 	secretManager := secret.NewManager(k, h)
 	for _, tls := range i.resource.TLS {
-		if tls.SecretName == "" {
+		// Certificates of client TLS auth are served from the crt-list
+		if tls.SecretName == "" || i.clientCert != nil {
 			continue
 		}
 		sec := secret.Secret{
@@ -218,11 +350,28 @@ func (i *Ingress) Update(k store.K8s, h haproxy.HAProxy, a annotations.Annotatio
 		return
 	}
 	logger.Tracef("Ingress '%s/%s': processing annotations...", i.resource.Namespace, i.resource.Name)
-	enabled, err := annotations.Bool("ssl-passthrough", i.resource.Annotations, k.ConfigMaps.Main.Annotations)
+	i.pathMatch = a.String("path-match", i.resource.Annotations, k.ConfigMaps.Main.Annotations)
+	switch i.pathMatch {
+	case "", route.PATH_MATCH_PREFIX, route.PATH_MATCH_REGEX, route.PATH_MATCH_REGEX_CASE_INSENSITIVE:
+	default:
+		logger.Errorf("Ingress '%s/%s': path-match: unknown value '%s', using '%s'", i.resource.Namespace, i.resource.Name, i.pathMatch, route.PATH_MATCH_PREFIX)
+		i.pathMatch = route.PATH_MATCH_PREFIX
+	}
+	i.canary, err = newCanary(i.resource.Annotations)
+	if err == nil && i.canary != nil && i.sslPassthrough {
+		err = errCanarySSLPassthrough
+	}
 	if err != nil {
-		logger.Errorf("Ingress '%s/%s': SSL Passthrough parsing: %s", i.resource.Namespace, i.resource.Name, err)
-	} else if enabled {
-		i.sslPassthrough = true
+		logger.Errorf("Ingress '%s/%s': canary: %s, ingress rules ignored", i.resource.Namespace, i.resource.Name, err)
+		return
+	}
+	i.routeMatch, err = newRouteMatch(i.resource.Annotations)
+	if err == nil && i.routeMatch != nil && i.sslPassthrough {
+		err = errors.New("request matching is not supported with ssl-passthrough")
+	}
+	if err != nil {
+		logger.Errorf("Ingress '%s/%s': %s, ingress rules ignored", i.resource.Namespace, i.resource.Name, err)
+		return
 	}
 	i.handleAnnotations(k, h)
 	// Ingress rules
@@ -234,6 +383,9 @@ func (i *Ingress) Update(k store.K8s, h haproxy.HAProxy, a annotations.Annotatio
 			}
 		}
 	}
+	if err := i.handleHostDefaultBackend(k, h, a); err != nil {
+		logger.Errorf("Ingress '%s/%s': host-default-backend: %s", i.resource.Namespace, i.resource.Name, err)
+	}
 }
 
 func (i Ingress) GetAddresses() []string {
@@ -0,0 +1,124 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingress
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/haproxytech/client-native/v6/models"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+// httpMethodRegex matches valid HTTP method names.
+var httpMethodRegex = regexp.MustCompile("^[A-Z]+$")
+
+// routeMatch holds the criteria, besides host and path, of the requests routed to the services of the ingress paths.
+// Each criterion is a named ACL of the main frontends and requests must match all of them.
+type routeMatch struct {
+	acls models.Acls
+}
+
+// newRouteMatch returns the request match criteria of the ingress, nil when the ingress has none.
+func newRouteMatch(ingressAnnotations map[string]string) (*routeMatch, error) {
+	m := &routeMatch{}
+	if err := m.addFieldMatches("route-match-headers", "req.fhdr", annotations.String("route-match-headers", ingressAnnotations)); err != nil {
+		return nil, err
+	}
+	if err := m.addFieldMatches("route-match-query-params", "urlp", annotations.String("route-match-query-params", ingressAnnotations)); err != nil {
+		return nil, err
+	}
+	if methods := annotations.String("route-match-methods", ingressAnnotations); methods != "" {
+		values := strings.FieldsFunc(methods, isListSeparator)
+		for _, method := range values {
+			if !httpMethodRegex.MatchString(method) {
+				return nil, fmt.Errorf("route-match-methods: invalid method '%s'", method)
+			}
+		}
+		m.addACL("method", "method", strings.Join(values, " "))
+	}
+	if sources := annotations.String("route-match-src", ingressAnnotations); sources != "" {
+		values := strings.FieldsFunc(sources, isListSeparator)
+		for _, src := range values {
+			if _, _, err := net.ParseCIDR(src); err != nil && net.ParseIP(src) == nil {
+				return nil, fmt.Errorf("route-match-src: invalid IP address or CIDR '%s'", src)
+			}
+		}
+		m.addACL("src", "src", strings.Join(values, " "))
+	}
+	if len(m.acls) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+	sort.Slice(m.acls, func(i, j int) bool { return m.acls[i].ACLName < m.acls[j].ACLName })
+	return m, nil
+}
+
+// addFieldMatches adds the ACLs of the lines of the annotation, each one matching a field of the requests:
+// "<name> <value>" for an exact match of the field value, "<name> regex <regex>" for a regex match.
+func (m *routeMatch) addFieldMatches(annotation, fetch, input string) error {
+	for _, line := range strings.Split(input, "\n") {
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0:
+			continue
+		case !httpTokenRegex.MatchString(fields[0]):
+			return fmt.Errorf("%s: invalid name '%s'", annotation, fields[0])
+		case len(fields) == 2:
+			if strings.ContainsAny(fields[1], "\"'\\#{}") {
+				return fmt.Errorf("%s: unsupported characters in '%s'", annotation, fields[1])
+			}
+			m.addACL(fetch, fmt.Sprintf("%s(%s)", fetch, fields[0]), "-m str "+fields[1])
+		case len(fields) == 3 && fields[1] == "regex":
+			if strings.ContainsAny(fields[2], "\"'#") {
+				return fmt.Errorf("%s: unsupported characters in regex '%s'", annotation, fields[2])
+			}
+			if _, err := regexp.Compile(fields[2]); err != nil {
+				return fmt.Errorf("%s: invalid regex '%s': %w", annotation, fields[2], err)
+			}
+			m.addACL(fetch, fmt.Sprintf("%s(%s)", fetch, fields[0]), "-m reg "+fields[2])
+		default:
+			return fmt.Errorf("%s: invalid line '%s', expected '<name> <value>' or '<name> regex <regex>'", annotation, line)
+		}
+	}
+	return nil
+}
+
+// addACL adds the named ACL of a criterion, the name is derived from the criterion
+// so that ingresses having the same criterion share the ACL.
+func (m *routeMatch) addACL(kind, criterion, value string) {
+	m.acls = append(m.acls, &models.ACL{
+		ACLName:   fmt.Sprintf("match_%s_%s", strings.ReplaceAll(kind, ".", "_"), utils.Hash([]byte(criterion+" "+value))),
+		Criterion: criterion,
+		Value:     value,
+	})
+}
+
+// condition returns the condition of the requests matching all the criteria.
+func (m routeMatch) condition() string {
+	names := make([]string, len(m.acls))
+	for i, acl := range m.acls {
+		names[i] = acl.ACLName
+	}
+	return strings.Join(names, " ")
+}
+
+func isListSeparator(r rune) bool {
+	return r == ',' || r == ' ' || r == '\t' || r == '\n'
+}
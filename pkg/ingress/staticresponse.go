@@ -0,0 +1,132 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingress
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	v3 "github.com/haproxytech/kubernetes-ingress/crs/api/ingress/v3"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/rules"
+	"github.com/haproxytech/kubernetes-ingress/pkg/route"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+)
+
+// handleStaticResponsePath routes the requests of a path whose backend is a StaticResponse custom resource.
+// The response is returned by HAProxy with a rule matched only for this path, no backend is involved.
+func (i *Ingress) handleStaticResponsePath(k store.K8s, h haproxy.HAProxy, host string, path *store.IngressPath) error {
+	resource := path.Resource
+	ns := k.GetNamespace(i.resource.Namespace)
+	spec, ok := ns.CRs.StaticResponses[resource.Name]
+	if !ok {
+		return fmt.Errorf("backend resource %s '%s/%s' not found", resource.Kind, i.resource.Namespace, resource.Name)
+	}
+	rule, err := staticResponseRule(*spec)
+	if err != nil {
+		return fmt.Errorf("backend resource %s '%s/%s': %w", resource.Kind, i.resource.Namespace, resource.Name, err)
+	}
+	// The backend name only fills the map entry, the rule answers before any backend is used.
+	ingRoute := route.Route{
+		Host:         host,
+		Path:         path,
+		HAProxyRules: append(addRules(rules.List{rule}, h, true), i.pathRuleIDs(path)...),
+		BackendName:  strings.ReplaceAll(fmt.Sprintf("staticresponse_%s_%s", i.resource.Namespace, resource.Name), ".", "_"),
+		PathMatch:    i.pathMatch,
+	}
+	return route.AddHostPathRoute(ingRoute, h.Maps)
+}
+
+// staticResponseRule returns the rule returning the response or redirecting requests as described by the spec.
+func staticResponseRule(spec v3.StaticResponseSpec) (rules.Rule, error) { //nolint:ireturn
+	if spec.Redirect != nil {
+		return staticRedirectRule(spec)
+	}
+	statusCode := spec.StatusCode
+	if statusCode == 0 {
+		statusCode = 200
+	}
+	if statusCode < 200 || statusCode > 599 {
+		return nil, fmt.Errorf("status code %d is not in the 200-599 range", statusCode)
+	}
+	rule := &rules.ReqReturn{
+		StatusCode: statusCode,
+		Content:    spec.Body,
+	}
+	if spec.Body != "" {
+		rule.ContentType = spec.ContentType
+		if rule.ContentType == "" {
+			rule.ContentType = rules.MIME_TYPE_TEXT_PLAIN
+		}
+	}
+	for _, header := range spec.Headers {
+		if header.Name == "" || strings.ContainsAny(header.Name, " \t:\"'\\#") {
+			return nil, fmt.Errorf("invalid header name '%s'", header.Name)
+		}
+		rule.Headers = append(rule.Headers, rules.ReturnHeader{
+			Name: header.Name,
+			Fmt:  strings.ReplaceAll(header.Value, "%", "%%"),
+		})
+	}
+	return rule, nil
+}
+
+// staticRedirectRule returns the rule redirecting requests to the location of the spec.
+func staticRedirectRule(spec v3.StaticResponseSpec) (*rules.RequestRedirect, error) {
+	statusCode := spec.StatusCode
+	if statusCode == 0 {
+		statusCode = 302
+	}
+	switch statusCode {
+	case 301, 302, 303, 307, 308:
+	default:
+		return nil, fmt.Errorf("redirect status code %d is not one of 301, 302, 303, 307 or 308", statusCode)
+	}
+	location, err := url.Parse(spec.Redirect.Location)
+	if err != nil {
+		return nil, fmt.Errorf("redirect location: %w", err)
+	}
+	if (location.Scheme != "http" && location.Scheme != "https") || location.Host == "" {
+		return nil, fmt.Errorf("redirect location '%s' is not an absolute http or https URL", spec.Redirect.Location)
+	}
+	if strings.ContainsAny(location.Host, "% \t\"'\\#") {
+		return nil, fmt.Errorf("redirect location '%s' has an invalid host", spec.Redirect.Location)
+	}
+	uri := strings.ReplaceAll(location.EscapedPath(), "%", "%%")
+	if spec.Redirect.KeepPath {
+		if location.RawQuery != "" {
+			return nil, errors.New("redirect location cannot have a query string when the path is kept")
+		}
+		uri = strings.TrimSuffix(uri, "/") + "%[capture.req.uri]"
+	} else {
+		if uri == "" {
+			uri = "/"
+		}
+		if location.RawQuery != "" {
+			uri += "?" + strings.ReplaceAll(location.RawQuery, "%", "%%")
+		}
+	}
+	if strings.ContainsAny(uri, " \t\"'\\#") {
+		return nil, fmt.Errorf("redirect location '%s' has unsupported characters", spec.Redirect.Location)
+	}
+	return &rules.RequestRedirect{
+		Host:         location.Host,
+		URI:          uri,
+		RedirectCode: statusCode,
+		SSLRequest:   location.Scheme == "https",
+	}, nil
+}
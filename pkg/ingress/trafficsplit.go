@@ -0,0 +1,136 @@
+// Copyright 2019 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingress
+
+import (
+	"errors"
+	"fmt"
+
+	v3 "github.com/haproxytech/kubernetes-ingress/crs/api/ingress/v3"
+	"github.com/haproxytech/kubernetes-ingress/pkg/annotations"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy"
+	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/rules"
+	"github.com/haproxytech/kubernetes-ingress/pkg/route"
+	"github.com/haproxytech/kubernetes-ingress/pkg/service"
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+	"github.com/haproxytech/kubernetes-ingress/pkg/utils"
+)
+
+// handleTrafficSplitPath routes the requests of a path whose backend is a TrafficSplit custom resource.
+// The host/path route leads to the last service, the other services are elected before it with a
+// random draw weighted by the remaining weights, so that each service gets its share of the requests.
+// With a cookie, requests carrying the backend name of a service are routed to it first.
+func (i *Ingress) handleTrafficSplitPath(k store.K8s, h haproxy.HAProxy, host string, path *store.IngressPath, a annotations.Annotations) error {
+	resource := path.Resource
+	ns := k.GetNamespace(i.resource.Namespace)
+	spec, ok := ns.CRs.TrafficSplits[resource.Name]
+	if !ok {
+		return fmt.Errorf("backend resource %s '%s/%s' not found", resource.Kind, i.resource.Namespace, resource.Name)
+	}
+	if err := checkTrafficSplit(*spec); err != nil {
+		return fmt.Errorf("backend resource %s '%s/%s': %w", resource.Kind, i.resource.Namespace, resource.Name, err)
+	}
+	services := []*service.Service{}
+	backendNames := []string{}
+	weights := []int64{}
+	for _, splitService := range spec.Services {
+		if splitService.Weight == 0 {
+			continue
+		}
+		svcPath := &store.IngressPath{
+			SvcNamespace:     i.resource.Namespace,
+			SvcName:          splitService.Name,
+			SvcPortInt:       splitService.Port.Number,
+			SvcPortString:    splitService.Port.Name,
+			Path:             path.Path,
+			PathTypeMatch:    path.PathTypeMatch,
+			IsDefaultBackend: path.IsDefaultBackend,
+		}
+		svc, backendName, err := i.handleService(k, h, svcPath, a)
+		if err != nil {
+			return fmt.Errorf("backend resource %s '%s/%s': %w", resource.Kind, i.resource.Namespace, resource.Name, err)
+		}
+		services = append(services, svc)
+		backendNames = append(backendNames, backendName)
+		weights = append(weights, splitService.Weight)
+	}
+	// The rule ID only identifies the requests of this host/path route in the map value.
+	splitID := rules.RuleID(utils.Hash([]byte(fmt.Sprintf("%s/%s/%s/%s", i.resource.Namespace, resource.Name, host, path.Path))))
+	ingRoute := route.Route{
+		Host:         host,
+		Path:         path,
+		HAProxyRules: append([]rules.RuleID{splitID}, i.pathRuleIDs(path)...),
+		BackendName:  backendNames[len(backendNames)-1],
+		PathMatch:    i.pathMatch,
+		Priority:     i.routePriority(nil),
+	}
+	if ingRoute.Priority < 0 {
+		// The draws must be evaluated before the host/path route leading to the last service.
+		logger.Errorf("Ingress '%s/%s': route-priority: negative priority not supported with %s, using 0", i.resource.Namespace, i.resource.Name, resource.Kind)
+		ingRoute.Priority = 0
+	}
+	if spec.Cookie != "" {
+		cookieRule := rules.ReqAddHdr{
+			Response:  true,
+			HdrName:   "Set-Cookie",
+			HdrFormat: fmt.Sprintf(`"%s=%%[be_name]; Path=/; HttpOnly"`, spec.Cookie),
+		}
+		ingRoute.HAProxyRules = append(addRules(rules.List{cookieRule}, h, true), ingRoute.HAProxyRules...)
+	}
+	if err := route.AddHostPathRoute(ingRoute, h.Maps); err != nil {
+		return err
+	}
+	// The cookies are evaluated before the draws, made in the order of the services.
+	if spec.Cookie != "" {
+		for _, backendName := range backendNames {
+			ingRoute.BackendName = backendName
+			route.AddRuleIDRoute(ingRoute, splitID, fmt.Sprintf("{ req.cook(%s) -m str %s }", spec.Cookie, backendName))
+		}
+	}
+	var remaining int64
+	for _, weight := range weights {
+		remaining += weight
+	}
+	for j := 0; j < len(weights)-1; j++ {
+		ingRoute.BackendName = backendNames[j]
+		route.AddRuleIDRoute(ingRoute, splitID, fmt.Sprintf("{ rand(%d) lt %d }", remaining, weights[j]))
+		remaining -= weights[j]
+	}
+	for j, svc := range services {
+		handleEndpoints(k, h, svc, backendNames[j])
+	}
+	return nil
+}
+
+// checkTrafficSplit returns an error if the spec can't be configured.
+func checkTrafficSplit(spec v3.TrafficSplitSpec) error {
+	if spec.Cookie != "" && !httpTokenRegex.MatchString(spec.Cookie) {
+		return fmt.Errorf("invalid cookie name '%s'", spec.Cookie)
+	}
+	var total int64
+	for _, splitService := range spec.Services {
+		if splitService.Weight < 0 {
+			return fmt.Errorf("service '%s' has a negative weight", splitService.Name)
+		}
+		if splitService.Port.Name == "" && splitService.Port.Number == 0 {
+			return fmt.Errorf("service '%s' has no port", splitService.Name)
+		}
+		total += splitService.Weight
+	}
+	if total == 0 {
+		return errors.New("no service with a positive weight")
+	}
+	return nil
+}
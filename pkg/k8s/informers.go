@@ -51,20 +51,26 @@ func (k k8s) getNamespaceInfomer(eventChan chan k8ssync.SyncDataEvent, factory i
 					Name:                     data.GetName(),
 					Endpoints:                make(map[string]map[string]*store.Endpoints),
 					Services:                 make(map[string]*store.Service),
+					ConfigMaps:               make(map[string]*store.ConfigMap),
 					Ingresses:                make(map[string]*store.Ingress),
 					Secret:                   make(map[string]*store.Secret),
 					HAProxyRuntime:           make(map[string]map[string]*store.RuntimeBackend),
 					HAProxyRuntimeStandalone: make(map[string]map[string]map[string]*store.RuntimeBackend),
 					CRs: &store.CustomResources{
-						Global:    make(map[string]*models.Global),
-						Defaults:  make(map[string]*models.Defaults),
-						Backends:  make(map[string]*v3.BackendSpec),
-						Frontends: make(map[string]*v3.FrontendSpec),
+						Global:          make(map[string]*models.Global),
+						Defaults:        make(map[string]*models.Defaults),
+						Backends:        make(map[string]*v3.BackendSpec),
+						Frontends:       make(map[string]*v3.FrontendSpec),
+						StaticResponses: make(map[string]*v3.StaticResponseSpec),
+						TrafficSplits:   make(map[string]*v3.TrafficSplitSpec),
 					},
 					Gateways:        make(map[string]*store.Gateway),
 					TCPRoutes:       make(map[string]*store.TCPRoute),
+					HTTPRoutes:      make(map[string]*store.HTTPRoute),
+					TLSRoutes:       make(map[string]*store.TLSRoute),
 					ReferenceGrants: make(map[string]*store.ReferenceGrant),
 					Labels:          utils.CopyMap(data.Labels),
+					Annotations:     utils.CopyMap(data.Annotations),
 					Status:          status,
 				}
 				logIncomingK8sEvent(logger, item, data.UID, data.ResourceVersion)
@@ -81,20 +87,26 @@ func (k k8s) getNamespaceInfomer(eventChan chan k8ssync.SyncDataEvent, factory i
 					Name:                     data.GetName(),
 					Endpoints:                make(map[string]map[string]*store.Endpoints),
 					Services:                 make(map[string]*store.Service),
+					ConfigMaps:               make(map[string]*store.ConfigMap),
 					Ingresses:                make(map[string]*store.Ingress),
 					Secret:                   make(map[string]*store.Secret),
 					HAProxyRuntime:           make(map[string]map[string]*store.RuntimeBackend),
 					HAProxyRuntimeStandalone: make(map[string]map[string]map[string]*store.RuntimeBackend),
 					CRs: &store.CustomResources{
-						Global:    make(map[string]*models.Global),
-						Defaults:  make(map[string]*models.Defaults),
-						Backends:  make(map[string]*v3.BackendSpec),
-						Frontends: make(map[string]*v3.FrontendSpec),
+						Global:          make(map[string]*models.Global),
+						Defaults:        make(map[string]*models.Defaults),
+						Backends:        make(map[string]*v3.BackendSpec),
+						Frontends:       make(map[string]*v3.FrontendSpec),
+						StaticResponses: make(map[string]*v3.StaticResponseSpec),
+						TrafficSplits:   make(map[string]*v3.TrafficSplitSpec),
 					},
 					Gateways:        make(map[string]*store.Gateway),
 					TCPRoutes:       make(map[string]*store.TCPRoute),
+					HTTPRoutes:      make(map[string]*store.HTTPRoute),
+					TLSRoutes:       make(map[string]*store.TLSRoute),
 					ReferenceGrants: make(map[string]*store.ReferenceGrant),
 					Labels:          utils.CopyMap(data.Labels),
+					Annotations:     utils.CopyMap(data.Annotations),
 					Status:          status,
 				}
 				logIncomingK8sEvent(logger, item, data.UID, data.ResourceVersion)
@@ -114,9 +126,10 @@ func (k k8s) getNamespaceInfomer(eventChan chan k8ssync.SyncDataEvent, factory i
 				status := store.MODIFIED
 
 				item2 := &store.Namespace{
-					Name:   data2.GetName(),
-					Status: status,
-					Labels: utils.CopyMap(data2.Labels),
+					Name:        data2.GetName(),
+					Status:      status,
+					Labels:      utils.CopyMap(data2.Labels),
+					Annotations: utils.CopyMap(data2.Annotations),
 				}
 				logIncomingK8sEvent(logger, item2, data2.UID, data2.ResourceVersion)
 				eventChan <- ToSyncDataEvent(item2, item2, data2.UID, data2.ResourceVersion)
@@ -886,7 +899,7 @@ type InformerGetter interface {
 }
 
 type GatewayRelatedType interface {
-	*gatewayv1beta1.GatewayClass | *gatewayv1beta1.Gateway | *gatewayv1alpha2.TCPRoute | *gatewayv1alpha2.ReferenceGrant
+	*gatewayv1beta1.GatewayClass | *gatewayv1beta1.Gateway | *gatewayv1alpha2.TCPRoute | *gatewayv1beta1.HTTPRoute | *gatewayv1alpha2.TLSRoute | *gatewayv1alpha2.ReferenceGrant
 }
 
 type GatewayInformerFunc[GWType GatewayRelatedType] func(gwObj GWType, eventChan chan k8ssync.SyncDataEvent, status store.Status)
@@ -900,6 +913,14 @@ func manageGatewayClass(gatewayclass *gatewayv1beta1.GatewayClass, eventChan cha
 		Generation:     gatewayclass.Generation,
 		Status:         status,
 	}
+	if parametersRef := gatewayclass.Spec.ParametersRef; parametersRef != nil {
+		item.ParametersRef = &store.GatewayClassParametersRef{
+			Namespace: (*string)(parametersRef.Namespace),
+			Group:     string(parametersRef.Group),
+			Kind:      string(parametersRef.Kind),
+			Name:      parametersRef.Name,
+		}
+	}
 	logger.Tracef("[RUNTIME] [K8s] %s %s: %s", k8ssync.GATEWAYCLASS, item.Status, item.Name)
 	eventChan <- k8ssync.SyncDataEvent{SyncType: k8ssync.GATEWAYCLASS, Data: &item}
 }
@@ -916,6 +937,22 @@ func manageGateway(gateway *gatewayv1beta1.Gateway, eventChan chan k8ssync.SyncD
 			GwNamespace: gateway.Namespace,
 			GwName:      gateway.Name,
 		}
+		if listener.TLS != nil {
+			listeners[i].TLS = &store.ListenerTLSConfig{
+				Mode: string(gatewayv1beta1.TLSModeTerminate),
+			}
+			if listener.TLS.Mode != nil {
+				listeners[i].TLS.Mode = string(*listener.TLS.Mode)
+			}
+			for _, certificateRef := range listener.TLS.CertificateRefs {
+				listeners[i].TLS.CertificateRefs = append(listeners[i].TLS.CertificateRefs, store.SecretObjectReference{
+					Group:     (*string)(certificateRef.Group),
+					Kind:      (*string)(certificateRef.Kind),
+					Namespace: (*string)(certificateRef.Namespace),
+					Name:      string(certificateRef.Name),
+				})
+			}
+		}
 		if listener.AllowedRoutes != nil {
 			listeners[i].AllowedRoutes = &store.AllowedRoutes{}
 			if listener.AllowedRoutes.Namespaces != nil {
@@ -939,11 +976,19 @@ func manageGateway(gateway *gatewayv1beta1.Gateway, eventChan chan k8ssync.SyncD
 			listeners[i].AllowedRoutes.Kinds = rgks
 		}
 	}
+	var addresses []store.GatewayAddress
+	for _, address := range gateway.Spec.Addresses {
+		addresses = append(addresses, store.GatewayAddress{
+			Type:  (*string)(address.Type),
+			Value: address.Value,
+		})
+	}
 	item := store.Gateway{
 		Name:             gateway.Name,
 		Namespace:        gateway.Namespace,
 		GatewayClassName: string(gateway.Spec.GatewayClassName),
 		Listeners:        listeners,
+		Addresses:        addresses,
 		Generation:       gateway.Generation,
 		Status:           status,
 	}
@@ -973,31 +1018,12 @@ func manageTCPRoute(tcproute *gatewayv1alpha2.TCPRoute, eventChan chan k8ssync.S
 	}
 	parentRefs := make([]store.ParentRef, 0, len(tcproute.Spec.ParentRefs))
 	for _, parentRefSpec := range tcproute.Spec.ParentRefs {
-		// Ensure ParentRefs is only about Gateway resources.
-		parentRefGroup := "gateway.networking.k8s.io"
-		if parentRefSpec.Group != nil {
-			parentRefGroup = *(*string)(parentRefSpec.Group)
-		}
-		parentRefKind := "Gateway"
-		if parentRefSpec.Kind != nil {
-			parentRefKind = *(*string)(parentRefSpec.Kind)
-		}
-		if parentRefGroup != "gateway.networking.k8s.io" || parentRefKind != "Gateway" {
+		parentRef, ok := convertParentRef(tcproute.Namespace, (*string)(parentRefSpec.Group), (*string)(parentRefSpec.Kind),
+			(*string)(parentRefSpec.Namespace), string(parentRefSpec.Name), (*string)(parentRefSpec.SectionName), (*int32)(parentRefSpec.Port))
+		if !ok {
 			logger.Errorf("invalid parent reference in tcproute '%s/%s': parent reference must of kind 'Gateway' from group 'gateway.networking.k8s.io'", tcproute.Namespace, tcproute.Name)
 			continue
 		}
-		parentRefNs := (*string)(parentRefSpec.Namespace)
-		if parentRefNs == nil {
-			parentRefNs = &tcproute.Namespace
-		}
-		parentRef := store.ParentRef{
-			Namespace:   parentRefNs,
-			Name:        string(parentRefSpec.Name),
-			SectionName: (*string)(parentRefSpec.SectionName),
-			Port:        (*int32)(parentRefSpec.Port),
-			Group:       parentRefGroup,
-			Kind:        parentRefKind,
-		}
 		parentRefs = append(parentRefs, parentRef)
 	}
 
@@ -1014,6 +1040,223 @@ func manageTCPRoute(tcproute *gatewayv1alpha2.TCPRoute, eventChan chan k8ssync.S
 	eventChan <- k8ssync.SyncDataEvent{SyncType: k8ssync.TCPROUTE, Namespace: item.Namespace, Data: &item}
 }
 
+func manageHTTPRoute(httproute *gatewayv1beta1.HTTPRoute, eventChan chan k8ssync.SyncDataEvent, status store.Status) {
+	logger.Debugf("gwapi: httproute: informers: got '%s/%s'", httproute.Namespace, httproute.Name)
+	hostnames := make([]string, len(httproute.Spec.Hostnames))
+	for i, hostname := range httproute.Spec.Hostnames {
+		hostnames[i] = string(hostname)
+	}
+	rules := make([]store.HTTPRouteRule, len(httproute.Spec.Rules))
+	for i, rule := range httproute.Spec.Rules {
+		matches := make([]store.HTTPRouteMatch, len(rule.Matches))
+		for j, match := range rule.Matches {
+			matches[j] = store.HTTPRouteMatch{
+				Method: (*string)(match.Method),
+			}
+			if match.Path != nil {
+				matches[j].Path = &store.HTTPPathMatch{
+					Type:  string(utils.PointerDefaultValueIfNil(match.Path.Type)),
+					Value: utils.PointerDefaultValueIfNil(match.Path.Value),
+				}
+				if matches[j].Path.Type == "" {
+					matches[j].Path.Type = string(gatewayv1beta1.PathMatchPathPrefix)
+				}
+				if matches[j].Path.Value == "" {
+					matches[j].Path.Value = "/"
+				}
+			}
+			for _, header := range match.Headers {
+				headerMatch := store.HTTPHeaderMatch{
+					Type:  string(utils.PointerDefaultValueIfNil(header.Type)),
+					Name:  string(header.Name),
+					Value: header.Value,
+				}
+				if headerMatch.Type == "" {
+					headerMatch.Type = string(gatewayv1beta1.HeaderMatchExact)
+				}
+				matches[j].Headers = append(matches[j].Headers, headerMatch)
+			}
+			for _, queryParam := range match.QueryParams {
+				queryParamMatch := store.HTTPQueryParamMatch{
+					Type:  string(utils.PointerDefaultValueIfNil(queryParam.Type)),
+					Name:  queryParam.Name,
+					Value: queryParam.Value,
+				}
+				if queryParamMatch.Type == "" {
+					queryParamMatch.Type = string(gatewayv1beta1.QueryParamMatchExact)
+				}
+				matches[j].QueryParams = append(matches[j].QueryParams, queryParamMatch)
+			}
+		}
+		backendRefs := make([]store.BackendRef, len(rule.BackendRefs))
+		for j, backendref := range rule.BackendRefs {
+			backendRefs[j] = store.BackendRef{
+				Name:      string(backendref.Name),
+				Namespace: (*string)(backendref.Namespace),
+				Port:      (*int32)(backendref.Port),
+				Group:     (*string)(backendref.Group),
+				Kind:      (*string)(backendref.Kind),
+				Weight:    backendref.Weight,
+			}
+		}
+		filters := make([]store.HTTPRouteFilter, len(rule.Filters))
+		for j, filter := range rule.Filters {
+			filters[j] = convertHTTPRouteFilter(filter)
+		}
+		rules[i] = store.HTTPRouteRule{
+			Matches:     matches,
+			Filters:     filters,
+			BackendRefs: backendRefs,
+		}
+	}
+	parentRefs := make([]store.ParentRef, 0, len(httproute.Spec.ParentRefs))
+	for _, parentRefSpec := range httproute.Spec.ParentRefs {
+		parentRef, ok := convertParentRef(httproute.Namespace, (*string)(parentRefSpec.Group), (*string)(parentRefSpec.Kind),
+			(*string)(parentRefSpec.Namespace), string(parentRefSpec.Name), (*string)(parentRefSpec.SectionName), (*int32)(parentRefSpec.Port))
+		if !ok {
+			logger.Errorf("invalid parent reference in httproute '%s/%s': parent reference must of kind 'Gateway' from group 'gateway.networking.k8s.io'", httproute.Namespace, httproute.Name)
+			continue
+		}
+		parentRefs = append(parentRefs, parentRef)
+	}
+
+	item := store.HTTPRoute{
+		Name:         httproute.Name,
+		Namespace:    httproute.Namespace,
+		Hostnames:    hostnames,
+		Rules:        rules,
+		ParentRefs:   parentRefs,
+		CreationTime: httproute.CreationTimestamp.Time,
+		Generation:   httproute.Generation,
+		Status:       status,
+	}
+	logger.Tracef("[RUNTIME] [K8s] %s %s: %s", k8ssync.HTTPROUTE, item.Status, item.Name)
+	eventChan <- k8ssync.SyncDataEvent{SyncType: k8ssync.HTTPROUTE, Namespace: item.Namespace, Data: &item}
+}
+
+func manageTLSRoute(tlsroute *gatewayv1alpha2.TLSRoute, eventChan chan k8ssync.SyncDataEvent, status store.Status) {
+	logger.Debugf("gwapi: tlsroute: informers: got '%s/%s'", tlsroute.Namespace, tlsroute.Name)
+	hostnames := make([]string, len(tlsroute.Spec.Hostnames))
+	for i, hostname := range tlsroute.Spec.Hostnames {
+		hostnames[i] = string(hostname)
+	}
+	backendRefs := []store.BackendRef{}
+	for _, rule := range tlsroute.Spec.Rules {
+		for _, backendref := range rule.BackendRefs {
+			backendRefs = append(backendRefs, store.BackendRef{
+				Name:      string(backendref.Name),
+				Namespace: (*string)(backendref.Namespace),
+				Port:      (*int32)(backendref.Port),
+				Group:     (*string)(backendref.Group),
+				Kind:      (*string)(backendref.Kind),
+				Weight:    backendref.Weight,
+			})
+		}
+	}
+	parentRefs := make([]store.ParentRef, 0, len(tlsroute.Spec.ParentRefs))
+	for _, parentRefSpec := range tlsroute.Spec.ParentRefs {
+		parentRef, ok := convertParentRef(tlsroute.Namespace, (*string)(parentRefSpec.Group), (*string)(parentRefSpec.Kind),
+			(*string)(parentRefSpec.Namespace), string(parentRefSpec.Name), (*string)(parentRefSpec.SectionName), (*int32)(parentRefSpec.Port))
+		if !ok {
+			logger.Errorf("invalid parent reference in tlsroute '%s/%s': parent reference must of kind 'Gateway' from group 'gateway.networking.k8s.io'", tlsroute.Namespace, tlsroute.Name)
+			continue
+		}
+		parentRefs = append(parentRefs, parentRef)
+	}
+
+	item := store.TLSRoute{
+		Name:         tlsroute.Name,
+		Namespace:    tlsroute.Namespace,
+		Hostnames:    hostnames,
+		BackendRefs:  backendRefs,
+		ParentRefs:   parentRefs,
+		CreationTime: tlsroute.CreationTimestamp.Time,
+		Generation:   tlsroute.Generation,
+		Status:       status,
+	}
+	logger.Tracef("[RUNTIME] [K8s] %s %s: %s", k8ssync.TLSROUTE, item.Status, item.Name)
+	eventChan <- k8ssync.SyncDataEvent{SyncType: k8ssync.TLSROUTE, Namespace: item.Namespace, Data: &item}
+}
+
+// convertParentRef converts the fields of a route parent reference into a store.ParentRef.
+// It returns false if the parent reference is not about a Gateway resource.
+func convertParentRef(routeNamespace string, group, kind, namespace *string, name string, sectionName *string, port *int32) (store.ParentRef, bool) {
+	parentRefGroup := "gateway.networking.k8s.io"
+	if group != nil {
+		parentRefGroup = *group
+	}
+	parentRefKind := "Gateway"
+	if kind != nil {
+		parentRefKind = *kind
+	}
+	if parentRefGroup != "gateway.networking.k8s.io" || parentRefKind != "Gateway" {
+		return store.ParentRef{}, false
+	}
+	if namespace == nil {
+		namespace = &routeNamespace
+	}
+	return store.ParentRef{
+		Namespace:   namespace,
+		Name:        name,
+		SectionName: sectionName,
+		Port:        port,
+		Group:       parentRefGroup,
+		Kind:        parentRefKind,
+	}, true
+}
+
+// convertHTTPRouteFilter converts a filter of an httproute rule into a store.HTTPRouteFilter.
+func convertHTTPRouteFilter(filter gatewayv1beta1.HTTPRouteFilter) store.HTTPRouteFilter {
+	item := store.HTTPRouteFilter{
+		Type: string(filter.Type),
+	}
+	if headerModifier := filter.RequestHeaderModifier; headerModifier != nil {
+		item.RequestHeaderModifier = &store.HTTPHeaderFilter{
+			Set:    convertHTTPHeaders(headerModifier.Set),
+			Add:    convertHTTPHeaders(headerModifier.Add),
+			Remove: headerModifier.Remove,
+		}
+	}
+	if redirect := filter.RequestRedirect; redirect != nil {
+		item.RequestRedirect = &store.HTTPRequestRedirectFilter{
+			Scheme:     redirect.Scheme,
+			Hostname:   (*string)(redirect.Hostname),
+			Path:       convertHTTPPathModifier(redirect.Path),
+			Port:       (*int32)(redirect.Port),
+			StatusCode: redirect.StatusCode,
+		}
+	}
+	if rewrite := filter.URLRewrite; rewrite != nil {
+		item.URLRewrite = &store.HTTPURLRewriteFilter{
+			Hostname: (*string)(rewrite.Hostname),
+			Path:     convertHTTPPathModifier(rewrite.Path),
+		}
+	}
+	return item
+}
+
+func convertHTTPHeaders(headers []gatewayv1beta1.HTTPHeader) []store.HTTPHeader {
+	items := make([]store.HTTPHeader, len(headers))
+	for i, header := range headers {
+		items[i] = store.HTTPHeader{
+			Name:  string(header.Name),
+			Value: header.Value,
+		}
+	}
+	return items
+}
+
+func convertHTTPPathModifier(modifier *gatewayv1beta1.HTTPPathModifier) *store.HTTPPathModifier {
+	if modifier == nil {
+		return nil
+	}
+	return &store.HTTPPathModifier{
+		Type:               string(modifier.Type),
+		ReplaceFullPath:    modifier.ReplaceFullPath,
+		ReplacePrefixMatch: modifier.ReplacePrefixMatch,
+	}
+}
+
 func (k k8s) getGatewayClassesInformer(eventChan chan k8ssync.SyncDataEvent, factory gatewaynetworking.SharedInformerFactory) cache.SharedIndexInformer {
 	informer := factory.Gateway().V1beta1().GatewayClasses()
 	PopulateInformer(eventChan, informer, GatewayInformerFunc[*gatewayv1beta1.GatewayClass](manageGatewayClass))
@@ -1032,6 +1275,18 @@ func (k k8s) getTCPRouteInformer(eventChan chan k8ssync.SyncDataEvent, factory g
 	return informer.Informer()
 }
 
+func (k k8s) getHTTPRouteInformer(eventChan chan k8ssync.SyncDataEvent, factory gatewaynetworking.SharedInformerFactory) cache.SharedIndexInformer {
+	informer := factory.Gateway().V1beta1().HTTPRoutes()
+	PopulateInformer(eventChan, informer, GatewayInformerFunc[*gatewayv1beta1.HTTPRoute](manageHTTPRoute))
+	return informer.Informer()
+}
+
+func (k k8s) getTLSRouteInformer(eventChan chan k8ssync.SyncDataEvent, factory gatewaynetworking.SharedInformerFactory) cache.SharedIndexInformer {
+	informer := factory.Gateway().V1alpha2().TLSRoutes()
+	PopulateInformer(eventChan, informer, GatewayInformerFunc[*gatewayv1alpha2.TLSRoute](manageTLSRoute))
+	return informer.Informer()
+}
+
 func PopulateInformer[IT InformerGetter, GWType GatewayRelatedType, GWF GatewayInformerFunc[GWType]](eventChan chan k8ssync.SyncDataEvent, informer IT, handler GWF) cache.SharedIndexInformer {
 	//revive:disable:unchecked-type-assertion
 	_, err := informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -55,6 +55,8 @@ const (
 	CRSGroupVersionV1   = "ingress.v1.haproxy.org/v1"
 	CRSGroupVersionV3   = "ingress.v3.haproxy.org/v3"
 	GATEWAY_API_VERSION = "v0.5.1" //nolint:golint,stylecheck
+	// ERROR_PAGES_LABEL is the label of the ConfigMaps of error pages watched by the controller.
+	ERROR_PAGES_LABEL = "haproxy.org/error-pages" //nolint:golint,stylecheck
 )
 
 var ErrIgnored = errors.New("ignored resource")
@@ -168,6 +170,8 @@ func New(osArgs utils.OSArgs, whitelist map[string]struct{}, publishSvc *utils.N
 	k.registerCoreCRV3(NewDefaultsCRV3())
 	k.registerCoreCRV3(NewBackendCRV3())
 	k.registerCoreCRV3(NewTCPCRV3())
+	k.registerCoreCRV3(NewStaticResponseCRV3())
+	k.registerCoreCRV3(NewTrafficSplitCRV3())
 	if osArgs.CustomValidationRules.Name != "" {
 		k.registerCoreCRV3(NewValidationCRV3())
 	}
@@ -287,6 +291,18 @@ func (k k8s) runConfigMapInformers(eventChan chan k8ssync.SyncDataEvent, stop ch
 	}
 }
 
+// runLabeledConfigMapInformer watches the ConfigMaps of the namespace having the label.
+func (k k8s) runLabeledConfigMapInformer(eventChan chan k8ssync.SyncDataEvent, stop chan struct{}, informersSynced *[]cache.InformerSynced, namespace, label string) {
+	factory := k8sinformers.NewSharedInformerFactoryWithOptions(k.builtInClient, k.cacheResyncPeriod, k8sinformers.WithNamespace(namespace),
+		k8sinformers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.LabelSelector = label
+		}))
+
+	cmi := k.getConfigMapInformer(eventChan, factory)
+	go cmi.Run(stop)
+	*informersSynced = append(*informersSynced, cmi.HasSynced)
+}
+
 func (k k8s) runInformers(eventChan chan k8ssync.SyncDataEvent, stop chan struct{}, namespace string, informersSynced *[]cache.InformerSynced, osArgs utils.OSArgs) {
 	factory := k8sinformers.NewSharedInformerFactoryWithOptions(k.builtInClient, k.cacheResyncPeriod, k8sinformers.WithNamespace(namespace))
 	// Core.V1 Resources
@@ -302,6 +318,7 @@ func (k k8s) runInformers(eventChan chan k8ssync.SyncDataEvent, stop chan struct
 	k.runConfigMapInformers(eventChan, stop, informersSynced, osArgs.ConfigMapTCPServices)
 	k.runConfigMapInformers(eventChan, stop, informersSynced, osArgs.ConfigMapErrorFiles)
 	k.runConfigMapInformers(eventChan, stop, informersSynced, osArgs.ConfigMapPatternFiles)
+	k.runLabeledConfigMapInformer(eventChan, stop, informersSynced, namespace, ERROR_PAGES_LABEL)
 
 	// Ingress and IngressClass Resources
 	ii, ici := k.getIngressInformers(eventChan, factory, osArgs)
@@ -346,6 +363,16 @@ func (k k8s) runInformersGwAPI(eventChan chan k8ssync.SyncDataEvent, stop chan s
 		go tcprouteInf.Run(stop)
 		*informersSynced = append(*informersSynced, tcprouteInf.HasSynced)
 	}
+	httprouteInf := k.getHTTPRouteInformer(eventChan, factory)
+	if httprouteInf != nil {
+		go httprouteInf.Run(stop)
+		*informersSynced = append(*informersSynced, httprouteInf.HasSynced)
+	}
+	tlsrouteInf := k.getTLSRouteInformer(eventChan, factory)
+	if tlsrouteInf != nil {
+		go tlsrouteInf.Run(stop)
+		*informersSynced = append(*informersSynced, tlsrouteInf.HasSynced)
+	}
 	referenceGrantInf := k.getReferenceGrantInformer(eventChan, factory)
 	if referenceGrantInf != nil {
 		go referenceGrantInf.Run(stop)
@@ -48,10 +48,14 @@ const (
 	CR_BACKEND      SyncType = "Backend"
 	CR_TCP          SyncType = "TCP"
 	CR_FRONTEND     SyncType = "Frontend"
+	CR_STATIC_RESP  SyncType = "StaticResponse"
+	CR_SPLIT        SyncType = "TrafficSplit"
 	PUBLISH_SERVICE SyncType = "PUBLISH_SERVICE"
 	GATEWAYCLASS    SyncType = "GATEWAYCLASS"
 	GATEWAY         SyncType = "GATEWAY"
 	TCPROUTE        SyncType = "TCPROUTE"
+	HTTPROUTE       SyncType = "HTTPROUTE"
+	TLSROUTE        SyncType = "TLSROUTE"
 	REFERENCEGRANT  SyncType = "REFERENCEGRANT"
 	CUSTOM_RESOURCE SyncType = "CUSTOM_RESOURCE"
 )
@@ -22,6 +22,9 @@ type PrometheusMetricsManager struct {
 
 	// runtime socket
 	runtimeSocketCounterVec *prometheus.CounterVec
+
+	// ingress paths rejected by the host/path ownership policies
+	rejectedIngressPathsGaugeVec *prometheus.GaugeVec
 }
 
 var (
@@ -49,6 +52,14 @@ func New() PrometheusMetricsManager {
 			[]string{"object", "result"},
 		)
 
+		rejectedIngressPathsGauge := promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "haproxy_ingress_rejected_paths",
+				Help: "The number of ingress paths rejected by the host/path ownership policies and the canary checks partitioned by ingress namespace, ingress name and reason",
+			},
+			[]string{"namespace", "ingress", "reason"},
+		)
+
 		unableToSyncGauge := promauto.NewGauge(prometheus.GaugeOpts{
 			Name: "haproxy_unable_to_sync_configuration",
 			Help: "1 = there's a pending haproxy configuration that is not valid so not applicable, 0 = haproxy configuration applied",
@@ -58,6 +69,8 @@ func New() PrometheusMetricsManager {
 			reloadsCounterVec:       reloadCounter,
 			runtimeSocketCounterVec: runtimeSocketCounter,
 			unableToSyncGauge:       unableToSyncGauge,
+
+			rejectedIngressPathsGaugeVec: rejectedIngressPathsGauge,
 		}
 	})
 	return pmm
@@ -86,3 +99,14 @@ func (pmm PrometheusMetricsManager) SetUnableSyncGauge() {
 func (pmm PrometheusMetricsManager) UnsetUnableSyncGauge() {
 	pmm.unableToSyncGauge.Set(float64(0))
 }
+
+// SetRejectedIngressPaths replaces the numbers of rejected paths, keyed by ingress namespace, ingress name and reason.
+func (pmm PrometheusMetricsManager) SetRejectedIngressPaths(rejected map[[3]string]int) {
+	if pmm.rejectedIngressPathsGaugeVec == nil {
+		return
+	}
+	pmm.rejectedIngressPathsGaugeVec.Reset()
+	for labels, count := range rejected {
+		pmm.rejectedIngressPathsGaugeVec.WithLabelValues(labels[0], labels[1], labels[2]).Set(float64(count))
+	}
+}
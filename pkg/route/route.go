@@ -17,6 +17,9 @@ package route
 import (
 	"errors"
 	"fmt"
+	"math"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/haproxytech/client-native/v6/models"
@@ -24,6 +27,7 @@ import (
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/api"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/maps"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/rules"
+	"github.com/haproxytech/kubernetes-ingress/pkg/rules/acls"
 	"github.com/haproxytech/kubernetes-ingress/pkg/store"
 )
 
@@ -38,11 +42,26 @@ const (
 	PATH_EXACT        maps.Name = "path-exact"
 	PATH_PREFIX_EXACT maps.Name = "path-prefix-exact"
 	PATH_PREFIX       maps.Name = "path-prefix"
+	PATH_REGEX        maps.Name = "path-regex"
+	// Ingresses in maintenance
+	MAINTENANCE maps.Name = "maintenance"
+	// Path match modes of ImplementationSpecific paths
+	PATH_MATCH_PREFIX                 = "prefix"
+	PATH_MATCH_REGEX                  = "regex"
+	PATH_MATCH_REGEX_CASE_INSENSITIVE = "regex-case-insensitive"
 )
 
+// SNILogFormat is the log format of frontends routing TLS connections on their SNI.
+const SNILogFormat = "'%ci:%cp [%t] %ft %b/%s %Tw/%Tc/%Tt %B %ts %ac/%fc/%bc/%sc/%rc %sq/%bq %hr %hs SNI: %[var(sess.sni)]'"
+
 var (
 	CurentCustomRoutes = make([]string, 0)
 	CustomRoutes       = make([]string, 0)
+	customRoutes       = make([]customRoute, 0)
+	frontendACLs       = make(map[string]*models.ACL)
+	hostDefaultRoutes  = make(map[string]Route)
+	hostDefaultOwners  = make(map[string]string)
+	fallbackBackends   = make(map[string]string)
 )
 
 type Route struct {
@@ -51,6 +70,20 @@ type Route struct {
 	BackendName    string
 	HAProxyRules   []rules.RuleID
 	SSLPassthrough bool
+	// PathMatch is the match mode of ImplementationSpecific paths, prefix when empty.
+	PathMatch string
+	// Priority orders the custom routes, the ones with a negative priority are evaluated after the host/path routes.
+	Priority int64
+}
+
+// customRoute is a use_backend rule of a custom route.
+type customRoute struct {
+	backendName string
+	condition   string
+	group       string
+	// pathMatch is the value of txn.path_match for the requests of the route, the one of the maps when empty.
+	pathMatch string
+	priority  int64
 }
 
 // AddHostPathRoute adds Host/Path ingress route to haproxy Map files used for backend switching.
@@ -62,10 +95,7 @@ func AddHostPathRoute(route Route, mapFiles maps.Maps) error {
 	if route.Host != "" && route.Host[0] == '*' {
 		route.Host = route.Host[1:]
 	}
-	value := route.BackendName
-	for _, id := range route.HAProxyRules {
-		value += "." + string(id)
-	}
+	value := pathMatchValue(route)
 	// SSLPassthrough
 	if route.SSLPassthrough {
 		if route.Host == "" {
@@ -84,6 +114,13 @@ func AddHostPathRoute(route Route, mapFiles maps.Maps) error {
 	switch {
 	case route.Path.PathTypeMatch == store.PATH_TYPE_EXACT:
 		mapFiles.MapAppend(PATH_EXACT, route.Host+path+"\t\t\t"+value)
+	case route.Path.PathTypeMatch == store.PATH_TYPE_IMPLEMENTATION_SPECIFIC &&
+		(route.PathMatch == PATH_MATCH_REGEX || route.PathMatch == PATH_MATCH_REGEX_CASE_INSENSITIVE):
+		key, err := pathRegexKey(route.Host, path, route.PathMatch == PATH_MATCH_REGEX_CASE_INSENSITIVE)
+		if err != nil {
+			return fmt.Errorf("backend '%s': %w", route.BackendName, err)
+		}
+		mapFiles.MapAppend(PATH_REGEX, key+"\t\t\t"+value)
 	case path == "" || path == "/":
 		mapFiles.MapAppend(PATH_PREFIX, route.Host+"/"+"\t\t\t"+value)
 	case route.Path.PathTypeMatch == store.PATH_TYPE_PREFIX:
@@ -100,8 +137,118 @@ func AddHostPathRoute(route Route, mapFiles maps.Maps) error {
 	return nil
 }
 
+// pathRegexKey returns the regex matched against the host and path of requests for the path regex.
+// The regex is anchored at the beginning of the path, like a prefix, and is not anchored at its end.
+func pathRegexKey(host, pathRegex string, caseInsensitive bool) (string, error) {
+	pathRegex = strings.TrimPrefix(pathRegex, "^")
+	if !strings.HasPrefix(pathRegex, "/") {
+		return "", fmt.Errorf("path regex '%s' must start with '/'", pathRegex)
+	}
+	if strings.ContainsAny(pathRegex, " \t\n\r") {
+		return "", fmt.Errorf("path regex '%s' must not contain white spaces", pathRegex)
+	}
+	if _, err := regexp.Compile(pathRegex); err != nil {
+		return "", fmt.Errorf("invalid path regex '%s': %w", pathRegex, err)
+	}
+	key := "^" + regexp.QuoteMeta(host) + pathRegex
+	if caseInsensitive {
+		key = "(?i)" + key
+	}
+	return key, nil
+}
+
+// SNIRules returns the rules electing the backend of a TLS connection from its SNI with the SNI map.
+// If not empty, keySuffix is appended to the SNI for the lookup so that SNI map entries can be scoped to a frontend.
+func SNIRules(inspectTimeout *int64, keySuffix string) []rules.Rule {
+	if keySuffix != "" {
+		keySuffix = fmt.Sprintf(",concat(%s)", keySuffix)
+	}
+	return []rules.Rule{
+		rules.ReqAcceptContent{},
+		rules.ReqInspectDelay{
+			Timeout: inspectTimeout,
+		},
+		rules.ReqSetVar{
+			Name:       "sni",
+			Scope:      "sess",
+			Expression: "req_ssl_sni",
+		},
+		rules.ReqSetVar{
+			Name:       "sni_match",
+			Scope:      "txn",
+			Expression: fmt.Sprintf("req_ssl_sni%s,map(%s)", keySuffix, maps.GetPath(SNI)),
+		},
+		rules.ReqSetVar{
+			Name:       "sni_match",
+			Scope:      "txn",
+			Expression: fmt.Sprintf("req_ssl_sni,regsub(^[^.]*,,)%s,map(%s)", keySuffix, maps.GetPath(SNI)),
+			CondTest:   "!{ var(txn.sni_match) -m found }",
+		},
+	}
+}
+
 // AddCustomRoute adds an ingress route with specific ACL via use_backend haproxy directive
-func AddCustomRoute(route Route, routeACLAnn string, api api.HAProxyClient) (err error) {
+func AddCustomRoute(route Route, routeACLAnn string) {
+	routeCond := fmt.Sprintf("%s { %s } ", hostPathCondition(route), routeACLAnn)
+	addCustomRoute(route, routeCond, routeCond+route.BackendName, pathMatchValue(route))
+}
+
+// AddConditionalRoute adds an ingress route via use_backend haproxy directive for each of the conditions, in their order.
+// Unlike AddCustomRoute, a condition can be made of several ACLs and must enclose its anonymous ACLs in braces.
+func AddConditionalRoute(route Route, conditions []string) {
+	hostPathCond := hostPathCondition(route)
+	for _, condition := range conditions {
+		addCustomRoute(route, fmt.Sprintf("%s %s ", hostPathCond, condition), hostPathCond+route.BackendName, pathMatchValue(route))
+	}
+}
+
+// AddRuleIDRoute adds a route via use_backend haproxy directive for the requests matching the condition
+// and whose host/path route, added with AddHostPathRoute, holds the ruleID.
+// Routes of the same ruleID are evaluated in the order they are added.
+func AddRuleIDRoute(route Route, ruleID rules.RuleID, condition string) {
+	addCustomRoute(route, fmt.Sprintf("{ var(%s) -m dom %s } %s ", rules.HTTPACLVar, ruleID, condition), string(ruleID), "")
+}
+
+// AddFrontendACLs adds named ACLs to the main frontends so that the conditions of custom routes can reference them.
+// ACLs with the same name are expected to be identical and are only added once.
+func AddFrontendACLs(acls models.Acls) {
+	for _, acl := range acls {
+		frontendACLs[acl.ACLName] = acl
+	}
+}
+
+// SetHostDefaultOwners sets the owner allowed to add the default route of each host.
+func SetHostDefaultOwners(owners map[string]string) {
+	hostDefaultOwners = owners
+}
+
+// AddHostDefaultRoute adds a route via use_backend haproxy directive for the requests of the host of the route
+// matching no other route. A host has a single default route, the one of its owner set by SetHostDefaultOwners.
+func AddHostDefaultRoute(route Route, owner string) error {
+	if hostOwner, ok := hostDefaultOwners[route.Host]; ok && hostOwner != owner {
+		return fmt.Errorf("default backend of host '%s' already set by '%s', ignored", route.Host, hostOwner)
+	}
+	hostDefaultRoutes[route.Host] = route
+	return nil
+}
+
+// AddFallbackRoute routes the requests of the backend to the fallback backend when the backend has no usable server.
+// A backend has a single fallback backend, the first one in alphabetical order is kept.
+func AddFallbackRoute(backendName, fallbackBackendName string) error {
+	if current, ok := fallbackBackends[backendName]; ok && current != fallbackBackendName {
+		ignored := fallbackBackendName
+		if fallbackBackendName < current {
+			ignored = current
+			fallbackBackends[backendName] = fallbackBackendName
+		}
+		return fmt.Errorf("fallback of backend '%s' set to '%s' and '%s', '%s' is ignored", backendName, current, fallbackBackendName, ignored)
+	}
+	fallbackBackends[backendName] = fallbackBackendName
+	return nil
+}
+
+// hostPathCondition returns the ACLs matching the host and the path of the route.
+func hostPathCondition(route Route) string {
 	var routeCond string
 	if route.Host != "" {
 		if route.Host[0] == '*' {
@@ -124,36 +271,189 @@ func AddCustomRoute(route Route, routeACLAnn string, api api.HAProxyClient) (err
 			}
 		}
 	}
-	routeCond = fmt.Sprintf("%s { %s } ", routeCond, routeACLAnn)
+	return routeCond
+}
+
+// addCustomRoute adds a use_backend rule written by CustomRoutesApply.
+// The group orders the routes of the same priority, routes of the same group keep their order.
+// The pathMatch is the value of txn.path_match for the requests of the route, see CustomRoutesRule.
+func addCustomRoute(route Route, routeCond, group, pathMatch string) {
+	customRoutes = append(customRoutes, customRoute{
+		backendName: route.BackendName,
+		condition:   routeCond,
+		group:       group,
+		pathMatch:   pathMatch,
+		priority:    route.Priority,
+	})
+	if route.Priority != 0 {
+		routeCond = fmt.Sprintf("%s(priority %d)", routeCond, route.Priority)
+	}
+	CustomRoutes = append(CustomRoutes, routeCond)
+}
 
+// CustomRoutesApply writes the custom routes in the main frontends by decreasing priority, then by group.
+// Routes with a negative priority are evaluated after the main use_backend rule, for requests not matching any host/path route,
+// followed by the default routes of the hosts.
+// Requests of a backend having a fallback backend are routed to the fallback backend when the backend has no usable server.
+func CustomRoutesApply(api api.HAProxyClient) (err error) {
+	frontendACLsApply(api)
+	hostDefaultRoutesAdd()
+	fallbacks := fallbackRoutes()
+	defer func() {
+		fallbackBackends = make(map[string]string)
+	}()
+	if len(customRoutes) == 0 && len(fallbacks) == 0 {
+		return err
+	}
+	sort.SliceStable(customRoutes, func(i, j int) bool {
+		if customRoutes[i].priority != customRoutes[j].priority {
+			return customRoutes[i].priority > customRoutes[j].priority
+		}
+		return customRoutes[i].group < customRoutes[j].group
+	})
 	for _, frontend := range []string{FrontendHTTP, FrontendHTTPS} {
-		err = api.BackendSwitchingRuleCreate(0, frontend, models.BackendSwitchingRule{
-			Cond:     "if",
-			CondTest: routeCond,
-			Name:     route.BackendName,
-		})
+		backendSwitchingRules := models.BackendSwitchingRules{}
+		mainRuleAdded := false
+		for _, route := range customRoutes {
+			if route.priority < 0 && !mainRuleAdded {
+				backendSwitchingRules = append(backendSwitchingRules, fallbacks...)
+				backendSwitchingRules = append(backendSwitchingRules, mainBackendSwitchingRule(true))
+				mainRuleAdded = true
+			}
+			if fallback, ok := fallbackBackends[route.backendName]; ok {
+				backendSwitchingRules = append(backendSwitchingRules, &models.BackendSwitchingRule{
+					Cond:     "if",
+					CondTest: fmt.Sprintf("%s { nbsrv(%s) eq 0 }", strings.TrimSpace(route.condition), route.backendName),
+					Name:     fallback,
+				})
+			}
+			backendSwitchingRules = append(backendSwitchingRules, &models.BackendSwitchingRule{
+				Cond:     "if",
+				CondTest: route.condition,
+				Name:     route.backendName,
+			})
+		}
+		if !mainRuleAdded {
+			backendSwitchingRules = append(backendSwitchingRules, fallbacks...)
+			backendSwitchingRules = append(backendSwitchingRules, mainBackendSwitchingRule(false))
+		}
+		err = api.BackendSwitchingRulesReplace(frontend, backendSwitchingRules)
 		if err != nil {
 			return err
 		}
 	}
-
-	CustomRoutes = append(CustomRoutes, routeCond)
 	return err
 }
 
+// CustomRoutesRule returns the rule setting txn.path_match for the requests of the custom routes written by CustomRoutesApply,
+// so that the ingress rules of these routes apply to them, nil without custom routes.
+func CustomRoutesRule() rules.Rule {
+	if len(customRoutes) == 0 {
+		return nil
+	}
+	rule := rules.ReqSetPathMatch{Routes: make([]rules.PathMatchRoute, len(customRoutes))}
+	for i, route := range customRoutes {
+		rule.Routes[i] = rules.PathMatchRoute{
+			Condition:     strings.TrimSpace(route.condition),
+			PathMatch:     route.pathMatch,
+			AfterHostPath: route.priority < 0,
+		}
+	}
+	return rule
+}
+
+// pathMatchValue returns the value of txn.path_match for the requests of the route: its backend followed by its rule IDs.
+func pathMatchValue(route Route) string {
+	value := route.BackendName
+	for _, id := range route.HAProxyRules {
+		value += "." + string(id)
+	}
+	return value
+}
+
+// hostDefaultRoutesAdd adds the default routes of the hosts after all the other routes,
+// the routes of the wildcard hosts being evaluated after the ones of the other hosts.
+func hostDefaultRoutesAdd() {
+	for host, hostDefault := range hostDefaultRoutes {
+		group := "0" + host
+		if host[0] == '*' {
+			group = "1" + host
+		}
+		routeCond := strings.TrimSpace(hostPathCondition(hostDefault))
+		customRoutes = append(customRoutes, customRoute{
+			backendName: hostDefault.BackendName,
+			condition:   routeCond,
+			group:       group,
+			priority:    math.MinInt64,
+		})
+		CustomRoutes = append(CustomRoutes, fmt.Sprintf("%s %s (host default)", routeCond, hostDefault.BackendName))
+	}
+	hostDefaultRoutes = make(map[string]Route)
+}
+
+// fallbackRoutes returns the rules using the fallback backend of the backend of the host/path route of the request
+// when this backend has no usable server, they are evaluated right before the main use_backend rule.
+func fallbackRoutes() models.BackendSwitchingRules {
+	backendNames := make([]string, 0, len(fallbackBackends))
+	for backendName := range fallbackBackends {
+		backendNames = append(backendNames, backendName)
+	}
+	sort.Strings(backendNames)
+	fallbacks := make(models.BackendSwitchingRules, 0, len(backendNames))
+	for _, backendName := range backendNames {
+		routeCond := fmt.Sprintf("{ var(%s),field(1,.) -m str %s } { nbsrv(%s) eq 0 }", rules.HTTPACLVar, backendName, backendName)
+		fallbacks = append(fallbacks, &models.BackendSwitchingRule{
+			Cond:     "if",
+			CondTest: routeCond,
+			Name:     fallbackBackends[backendName],
+		})
+		CustomRoutes = append(CustomRoutes, fmt.Sprintf("%s %s (fallback)", routeCond, fallbackBackends[backendName]))
+	}
+	return fallbacks
+}
+
+// frontendACLsApply writes the named ACLs added during the sync in the main frontends, removing the ones no longer used.
+func frontendACLsApply(api api.HAProxyClient) {
+	names := make([]string, 0, len(frontendACLs))
+	for name := range frontendACLs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	frontendACLList := make(models.Acls, 0, len(names))
+	for _, name := range names {
+		frontendACLList = append(frontendACLList, frontendACLs[name])
+	}
+	for _, frontend := range []string{FrontendHTTP, FrontendHTTPS} {
+		acls.PopulateFrontend(api, frontend, frontendACLList)
+	}
+	frontendACLs = make(map[string]*models.ACL)
+}
+
+// mainBackendSwitchingRule returns the rule using the backend of the host/path route of the request.
+// Without route, the rule ends the evaluation with the default backend, unless it is only used when a route is found.
+func mainBackendSwitchingRule(onlyWhenFound bool) *models.BackendSwitchingRule {
+	rule := &models.BackendSwitchingRule{
+		Name: "%[var(txn.path_match),field(1,.)]",
+	}
+	if onlyWhenFound {
+		rule.Cond = "if"
+		rule.CondTest = fmt.Sprintf("{ var(%s) -m found }", rules.HTTPACLVar)
+	}
+	return rule
+}
+
 func CustomRoutesReset(api api.HAProxyClient) (err error) {
 	for _, frontend := range []string{FrontendHTTP, FrontendHTTPS} {
 		err = api.BackendSwitchingRuleDeleteAll(frontend)
 		if err != nil {
 			break
 		}
-		err = api.BackendSwitchingRuleCreate(0, frontend, models.BackendSwitchingRule{
-			Name: "%[var(txn.path_match),field(1,.)]",
-		})
+		err = api.BackendSwitchingRuleCreate(0, frontend, *mainBackendSwitchingRule(false))
 		if err != nil {
 			return fmt.Errorf("unable to create main backendSwitching rule !!: %w", err)
 		}
 	}
 	CustomRoutes = make([]string, 0)
+	customRoutes = customRoutes[:0]
 	return err
 }
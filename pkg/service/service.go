@@ -29,6 +29,7 @@ import (
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/api"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/certs"
 	"github.com/haproxytech/kubernetes-ingress/pkg/haproxy/instance"
+	haproxyrules "github.com/haproxytech/kubernetes-ingress/pkg/haproxy/rules"
 	"github.com/haproxytech/kubernetes-ingress/pkg/rules"
 	"github.com/haproxytech/kubernetes-ingress/pkg/rules/acls"
 	"github.com/haproxytech/kubernetes-ingress/pkg/rules/filters"
@@ -270,6 +271,20 @@ func (s *Service) getBackendModel(store store.K8s, a annotations.Annotations, cl
 		if cookieErr := cookieAnn.Process(store, s.resource.Annotations, store.ConfigMaps.Main.Annotations); cookieErr != nil {
 			logger.Errorf("service '%s/%s': annotation '%s': %s", s.resource.Namespace, s.resource.Name, cookieAnn.GetName(), cookieErr)
 		}
+		// error-pages ConfigMaps of Service and Ingress annotations are restricted to
+		// the namespace of the Service, only the controller ConfigMap, managed by the
+		// cluster administrator, can reference a ConfigMap of another namespace.
+		// Processed after error-pages-redirect which takes precedence on its code.
+		if mode == "http" {
+			anyNamespace := s.resource.Annotations["error-pages"] == "" && (s.ingress == nil || s.ingress.Annotations["error-pages"] == "")
+			errorPages := serviceann.NewErrorPages("error-pages", s.resource.Namespace, anyNamespace, &backend.Backend)
+			if errorPagesErr := errorPages.Process(store, s.annotations...); errorPagesErr != nil {
+				logger.Errorf("service '%s/%s': annotation '%s': %s", s.resource.Namespace, s.resource.Name, errorPages.GetName(), errorPagesErr)
+			}
+		}
+		if mode == "http" && s.setTimeoutUsed(store) {
+			backend.HTTPRequestRuleList = append(backend.HTTPRequestRuleList, haproxyrules.SetTimeoutBackendRules()...)
+		}
 	}
 
 	// Manadatory backend params
@@ -305,6 +320,31 @@ func (s *Service) getBackendModel(store store.K8s, a annotations.Annotations, cl
 	return backend, nil
 }
 
+// setTimeoutUsed returns whether the ConfigMap or one of the ingresses of the service sets the set-timeout annotations,
+// whose timeouts are applied by the backend. All the ingresses of the service are checked so that the backend of
+// the service is the same whatever the ingress being processed.
+func (s *Service) setTimeoutUsed(k store.K8s) bool {
+	hasSetTimeout := func(annotations map[string]string) bool {
+		return annotations["set-timeout-server"] != "" || annotations["set-timeout-tunnel"] != ""
+	}
+	if hasSetTimeout(k.ConfigMaps.Main.Annotations) {
+		return true
+	}
+	if s.IsStandalone() && s.ingress != nil {
+		return hasSetTimeout(s.ingress.Annotations)
+	}
+	ingresses := k.IngressesByService[s.resource.Namespace+"/"+s.resource.Name]
+	if ingresses == nil {
+		return false
+	}
+	for _, ing := range ingresses.Items() {
+		if hasSetTimeout(ing.Annotations) {
+			return true
+		}
+	}
+	return false
+}
+
 // SetDefaultBackend configures the default service in kubernetes ingress resource as haproxy default backend of the frontends in params.
 func (s *Service) SetDefaultBackend(k store.K8s, h haproxy.HAProxy, frontends []string, a annotations.Annotations) (err error) {
 	if !s.path.IsDefaultBackend {
@@ -0,0 +1,48 @@
+package status
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/haproxytech/kubernetes-ingress/pkg/store"
+)
+
+// EventRecorder reports Kubernetes events about the resources handled by the controller.
+type EventRecorder interface {
+	IngressWarning(ing *store.Ingress, reason, message string)
+}
+
+type EventRecorderImpl struct {
+	recorder record.EventRecorder
+}
+
+// NewEventRecorder returns an EventRecorder writing events with the client,
+// events are only logged without client.
+func NewEventRecorder(client *kubernetes.Clientset) EventRecorder {
+	if client == nil {
+		return &EventRecorderImpl{}
+	}
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: client.CoreV1().Events("")})
+	return &EventRecorderImpl{
+		recorder: broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "haproxy-ingress-controller"}),
+	}
+}
+
+func (r *EventRecorderImpl) IngressWarning(ing *store.Ingress, reason, message string) {
+	logger.Warningf("Ingress '%s/%s': %s: %s", ing.Namespace, ing.Name, reason, message)
+	if r.recorder == nil {
+		return
+	}
+	r.recorder.Event(&corev1.ObjectReference{
+		Kind:       "Ingress",
+		APIVersion: networkingv1.SchemeGroupVersion.String(),
+		Namespace:  ing.Namespace,
+		Name:       ing.Name,
+		UID:        ing.UID,
+	}, corev1.EventTypeWarning, reason, message)
+}
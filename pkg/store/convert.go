@@ -19,6 +19,7 @@ import (
 	"strings"
 
 	"github.com/haproxytech/kubernetes-ingress/pkg/annotations/validators"
+	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	ammeta "k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
@@ -104,11 +105,16 @@ func (n ingressNetworkingV1Strategy) ConvertIngress(enableUserAnnotations bool)
 						if k8sPath.PathType != nil {
 							pathType = string(*k8sPath.PathType)
 						}
-						if k8sPath.Backend.Service == nil {
-							logger.Errorf("backend in ingress '%s/%s' should have service but none found", n.ig.GetNamespace(), n.ig.GetName())
+						if k8sPath.Backend.Service == nil && k8sPath.Backend.Resource == nil {
+							logger.Errorf("backend in ingress '%s/%s' should have service or resource but none found", n.ig.GetNamespace(), n.ig.GetName())
 							continue
 						}
-						pathKey := pathType + "-" + k8sPath.Path + "-" + k8sPath.Backend.Service.Name + "-" + k8sPath.Backend.Service.Port.Name
+						var pathKey string
+						if k8sPath.Backend.Service != nil {
+							pathKey = pathType + "-" + k8sPath.Path + "-" + k8sPath.Backend.Service.Name + "-" + k8sPath.Backend.Service.Port.Name
+						} else {
+							pathKey = pathType + "-" + k8sPath.Path + "-" + k8sPath.Backend.Resource.Kind + "-" + k8sPath.Backend.Resource.Name
+						}
 						paths[pathKey] = &IngressPath{
 							Path:          k8sPath.Path,
 							PathTypeMatch: pathType,
@@ -118,6 +124,8 @@ func (n ingressNetworkingV1Strategy) ConvertIngress(enableUserAnnotations bool)
 							paths[pathKey].SvcName = k8sPath.Backend.Service.Name
 							paths[pathKey].SvcPortInt = int64(k8sPath.Backend.Service.Port.Number)
 							paths[pathKey].SvcPortString = k8sPath.Backend.Service.Port.Name
+						} else {
+							paths[pathKey].Resource = convertIngressPathResource(k8sPath.Backend.Resource)
 						}
 					}
 					if rule, ok := rules[k8sRule.Host]; ok {
@@ -145,6 +153,8 @@ func (n ingressNetworkingV1Strategy) ConvertIngress(enableUserAnnotations bool)
 					ingPath.SvcName = ingressBackend.Service.Name
 					ingPath.SvcPortInt = int64(ingressBackend.Service.Port.Number)
 					ingPath.SvcPortString = ingressBackend.Service.Port.Name
+				} else if ingressBackend.Resource != nil {
+					ingPath.Resource = convertIngressPathResource(ingressBackend.Resource)
 				}
 				return ingPath
 			}(n.ig.Spec.DefaultBackend),
@@ -161,6 +171,7 @@ func (n ingressNetworkingV1Strategy) ConvertIngress(enableUserAnnotations bool)
 				return tls
 			}(n.ig.Spec.TLS),
 			CreationTime: n.ig.CreationTimestamp.Time,
+			UID:          n.ig.GetUID(),
 		},
 	}
 	addresses := []string{}
@@ -188,6 +199,17 @@ func (n ingressNetworkingV1Strategy) ConvertClass() *IngressClass {
 	}
 }
 
+func convertIngressPathResource(resource *corev1.TypedLocalObjectReference) *IngressPathResource {
+	pathResource := &IngressPathResource{
+		Kind: resource.Kind,
+		Name: resource.Name,
+	}
+	if resource.APIGroup != nil {
+		pathResource.APIGroup = *resource.APIGroup
+	}
+	return pathResource
+}
+
 func getIgClass(className *string) string {
 	if className == nil {
 		return ""
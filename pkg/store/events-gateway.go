@@ -124,6 +124,78 @@ func (k *K8s) EventTCPRoute(ns *Namespace, data *TCPRoute) (updateRequired bool)
 	return updateRequired
 }
 
+func (k *K8s) EventHTTPRoute(ns *Namespace, data *HTTPRoute) (updateRequired bool) {
+	switch data.Status {
+	case ADDED:
+		if previous := ns.HTTPRoutes[data.Name]; previous != nil {
+			logger.Warningf("Replacing existing httproute %s", data.Name)
+		}
+		ns.HTTPRoutes[data.Name] = data
+		updateRequired = true
+	case DELETED:
+		if previous := ns.HTTPRoutes[data.Name]; previous == nil {
+			logger.Warningf("Trying to delete unexisting httproute %s", data.Name)
+			return updateRequired
+		}
+		// We can't remove directly because we need the listener attached to this route to be updated.
+		ns.HTTPRoutes[data.Name] = data
+		updateRequired = true
+	case MODIFIED:
+		newHTTPRoute := data
+		oldHTTPRoute, ok := ns.HTTPRoutes[data.Name]
+		if !ok {
+			// It can happen (resync) that we receive an UPDATE on a item that is not yet registered
+			// We should treat it as a CREATE.
+			logger.Warningf("Modification of unexisting httproute %s", data.Name)
+			data.Status = ADDED
+			return k.EventHTTPRoute(ns, data)
+		}
+		if ok && newHTTPRoute.Generation == oldHTTPRoute.Generation ||
+			newHTTPRoute.Equal(oldHTTPRoute) {
+			return false
+		}
+		ns.HTTPRoutes[data.Name] = newHTTPRoute
+		updateRequired = true
+	}
+	return updateRequired
+}
+
+func (k *K8s) EventTLSRoute(ns *Namespace, data *TLSRoute) (updateRequired bool) {
+	switch data.Status {
+	case ADDED:
+		if previous := ns.TLSRoutes[data.Name]; previous != nil {
+			logger.Warningf("Replacing existing tlsroute %s", data.Name)
+		}
+		ns.TLSRoutes[data.Name] = data
+		updateRequired = true
+	case DELETED:
+		if previous := ns.TLSRoutes[data.Name]; previous == nil {
+			logger.Warningf("Trying to delete unexisting tlsroute %s", data.Name)
+			return updateRequired
+		}
+		// We can't remove directly because we need the listener attached to this route to be updated.
+		ns.TLSRoutes[data.Name] = data
+		updateRequired = true
+	case MODIFIED:
+		newTLSRoute := data
+		oldTLSRoute, ok := ns.TLSRoutes[data.Name]
+		if !ok {
+			// It can happen (resync) that we receive an UPDATE on a item that is not yet registered
+			// We should treat it as a CREATE.
+			logger.Warningf("Modification of unexisting tlsroute %s", data.Name)
+			data.Status = ADDED
+			return k.EventTLSRoute(ns, data)
+		}
+		if ok && newTLSRoute.Generation == oldTLSRoute.Generation ||
+			newTLSRoute.Equal(oldTLSRoute) {
+			return false
+		}
+		ns.TLSRoutes[data.Name] = newTLSRoute
+		updateRequired = true
+	}
+	return updateRequired
+}
+
 func (k *K8s) EventReferenceGrant(ns *Namespace, data *ReferenceGrant) (updateRequired bool) {
 	switch data.Status {
 	case ADDED:
@@ -29,12 +29,14 @@ func (k *K8s) EventNamespace(ns *Namespace, data *Namespace) (updateRequired boo
 	case ADDED:
 		nsStore := k.GetNamespace(data.Name)
 		nsStore.Labels = utils.CopyMap(data.Labels)
+		nsStore.Annotations = utils.CopyMap(data.Annotations)
 		updateRequired = true
 	case MODIFIED:
 		nsStore := k.GetNamespace(data.Name)
-		updateRequired = !utils.EqualMap(nsStore.Labels, data.Labels)
+		updateRequired = !utils.EqualMap(nsStore.Labels, data.Labels) || !utils.EqualMap(nsStore.Annotations, data.Annotations)
 		if updateRequired {
 			nsStore.Labels = utils.CopyMap(data.Labels)
+			nsStore.Annotations = utils.CopyMap(data.Annotations)
 		}
 	case DELETED:
 		_, ok := k.Namespaces[data.Name]
@@ -266,6 +268,28 @@ func (k *K8s) EventService(ns *Namespace, data *Service) (updateRequired bool) {
 	return updateRequired
 }
 
+// eventNamespaceConfigMap stores the labeled ConfigMaps of the namespace, like the error pages ones.
+func (k *K8s) eventNamespaceConfigMap(ns *Namespace, data *ConfigMap) (updateRequired bool) {
+	switch data.Status {
+	case ADDED, MODIFIED:
+		if ns.ConfigMaps[data.Name].Equal(data) {
+			return false
+		}
+		data.Loaded = true
+		ns.ConfigMaps[data.Name] = data
+		logger.Debugf("configmap '%s/%s' processed", data.Namespace, data.Name)
+		return true
+	case DELETED:
+		if _, ok := ns.ConfigMaps[data.Name]; !ok {
+			return false
+		}
+		delete(ns.ConfigMaps, data.Name)
+		logger.Debugf("configmap '%s/%s' deleted", data.Namespace, data.Name)
+		return true
+	}
+	return false
+}
+
 func (k *K8s) EventConfigMap(ns *Namespace, data *ConfigMap) (updateRequired bool) {
 	var cm *ConfigMap
 	switch {
@@ -278,7 +302,7 @@ func (k *K8s) EventConfigMap(ns *Namespace, data *ConfigMap) (updateRequired boo
 	case k.ConfigMaps.PatternFiles.Namespace == ns.Name && k.ConfigMaps.PatternFiles.Name == data.Name:
 		cm = k.ConfigMaps.PatternFiles
 	default:
-		return false
+		return k.eventNamespaceConfigMap(ns, data)
 	}
 	switch data.Status {
 	case ADDED:
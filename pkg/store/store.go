@@ -174,21 +174,27 @@ func (k K8s) GetNamespace(name string) *Namespace {
 		Relevant:                 k.isRelevantNamespace(name),
 		Endpoints:                make(map[string]map[string]*Endpoints),
 		Services:                 make(map[string]*Service),
+		ConfigMaps:               make(map[string]*ConfigMap),
 		Ingresses:                make(map[string]*Ingress),
 		Secret:                   make(map[string]*Secret),
 		HAProxyRuntime:           make(map[string]map[string]*RuntimeBackend),
 		HAProxyRuntimeStandalone: make(map[string]map[string]map[string]*RuntimeBackend),
 		CRs: &CustomResources{
-			Global:    make(map[string]*models.Global),
-			Defaults:  make(map[string]*models.Defaults),
-			Backends:  make(map[string]*v3.BackendSpec),
-			TCPsPerCR: make(map[string]*TCPs),
-			Frontends: make(map[string]*v3.FrontendSpec),
+			Global:          make(map[string]*models.Global),
+			Defaults:        make(map[string]*models.Defaults),
+			Backends:        make(map[string]*v3.BackendSpec),
+			TCPsPerCR:       make(map[string]*TCPs),
+			Frontends:       make(map[string]*v3.FrontendSpec),
+			StaticResponses: make(map[string]*v3.StaticResponseSpec),
+			TrafficSplits:   make(map[string]*v3.TrafficSplitSpec),
 		},
 		Gateways:        make(map[string]*Gateway),
 		TCPRoutes:       make(map[string]*TCPRoute),
+		HTTPRoutes:      make(map[string]*HTTPRoute),
+		TLSRoutes:       make(map[string]*TLSRoute),
 		ReferenceGrants: make(map[string]*ReferenceGrant),
 		Labels:          make(map[string]string),
+		Annotations:     make(map[string]string),
 		Status:          ADDED,
 	}
 	k.Namespaces[name] = newNamespace
@@ -166,7 +166,16 @@ func (gwc *GatewayClass) Equal(other *GatewayClass) bool {
 	return gwc == nil && other == nil || (NoNilPointer(gwc, other) &&
 		gwc.Name == other.Name &&
 		gwc.ControllerName == other.ControllerName &&
-		utils.EqualPointers(gwc.Description, other.Description))
+		utils.EqualPointers(gwc.Description, other.Description) &&
+		gwc.ParametersRef.Equal(other.ParametersRef))
+}
+
+func (ref *GatewayClassParametersRef) Equal(other *GatewayClassParametersRef) bool {
+	return ref == nil && other == nil || (NoNilPointer(ref, other) &&
+		ref.Group == other.Group &&
+		ref.Kind == other.Kind &&
+		ref.Name == other.Name &&
+		utils.EqualPointers(ref.Namespace, other.Namespace))
 }
 
 func (gw *Gateway) Equal(other *Gateway) bool {
@@ -174,7 +183,22 @@ func (gw *Gateway) Equal(other *Gateway) bool {
 		gw.Name == other.Name &&
 		gw.Namespace == other.Namespace &&
 		gw.GatewayClassName == other.GatewayClassName &&
-		Listeners(gw.Listeners).Equal(other.Listeners))
+		Listeners(gw.Listeners).Equal(other.Listeners) &&
+		GatewayAddresses(gw.Addresses).Equal(other.Addresses))
+}
+
+type GatewayAddresses []GatewayAddress
+
+func (addresses GatewayAddresses) Equal(other GatewayAddresses) bool {
+	if len(addresses) != len(other) {
+		return false
+	}
+	for i := range addresses {
+		if addresses[i].Value != other[i].Value || !utils.EqualPointers(addresses[i].Type, other[i].Type) {
+			return false
+		}
+	}
+	return true
 }
 
 type Listeners []Listener
@@ -217,9 +241,35 @@ func (listener *Listener) Equal(other *Listener) bool {
 		listener.Port == other.Port &&
 		listener.Protocol == other.Protocol &&
 		utils.EqualPointers(listener.Hostname, other.Hostname) &&
+		listener.TLS.Equal(other.TLS) &&
 		listener.AllowedRoutes.Equal(other.AllowedRoutes))
 }
 
+func (tls *ListenerTLSConfig) Equal(other *ListenerTLSConfig) bool {
+	return tls == nil && other == nil || (NoNilPointer(tls, other) &&
+		tls.Mode == other.Mode && SecretObjectReferences(tls.CertificateRefs).Equal(other.CertificateRefs))
+}
+
+type SecretObjectReferences []SecretObjectReference
+
+// Equal compares the references in order as the first certificate is the default one.
+func (refs SecretObjectReferences) Equal(other SecretObjectReferences) bool {
+	if len(refs) != len(other) {
+		return false
+	}
+	for i := range refs {
+		if !refs[i].Equal(other[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (ref SecretObjectReference) Equal(other SecretObjectReference) bool {
+	return ref.Name == other.Name && utils.EqualPointers(ref.Namespace, other.Namespace) &&
+		utils.EqualPointers(ref.Group, other.Group) && utils.EqualPointers(ref.Kind, other.Kind)
+}
+
 func (ar *AllowedRoutes) Equal(other *AllowedRoutes) bool {
 	return ar == nil && other == nil ||
 		(NoNilPointer(ar, other) && ar.Namespaces.Equal(other.Namespaces) && RouteGroupKinds(ar.Kinds).Equal(other.Kinds))
@@ -242,6 +292,71 @@ func (tcp *TCPRoute) Equal(other *TCPRoute) bool {
 		ParentRefs(tcp.ParentRefs).Equal(other.ParentRefs)
 }
 
+func (http *HTTPRoute) Equal(other *HTTPRoute) bool {
+	return http == nil && other == nil || (NoNilPointer(http, other) &&
+		http.Name == other.Name && http.Namespace == other.Namespace &&
+		utils.EqualSliceComparable(http.Hostnames, other.Hostnames) &&
+		utils.EqualSlice(http.Rules, other.Rules) &&
+		ParentRefs(http.ParentRefs).Equal(other.ParentRefs))
+}
+
+func (rule HTTPRouteRule) Equal(other HTTPRouteRule, opt ...models.Options) bool {
+	return utils.EqualSlice(rule.Matches, other.Matches) &&
+		utils.EqualSlice(rule.Filters, other.Filters) &&
+		BackendRefs(rule.BackendRefs).Equal(other.BackendRefs)
+}
+
+func (filter HTTPRouteFilter) Equal(other HTTPRouteFilter, opt ...models.Options) bool {
+	return filter.Type == other.Type &&
+		filter.RequestHeaderModifier.Equal(other.RequestHeaderModifier) &&
+		filter.RequestRedirect.Equal(other.RequestRedirect) &&
+		filter.URLRewrite.Equal(other.URLRewrite)
+}
+
+func (filter *HTTPHeaderFilter) Equal(other *HTTPHeaderFilter) bool {
+	return filter == nil && other == nil || (NoNilPointer(filter, other) &&
+		utils.EqualSliceComparable(filter.Set, other.Set) &&
+		utils.EqualSliceComparable(filter.Add, other.Add) &&
+		utils.EqualSliceComparable(filter.Remove, other.Remove))
+}
+
+func (filter *HTTPRequestRedirectFilter) Equal(other *HTTPRequestRedirectFilter) bool {
+	return filter == nil && other == nil || (NoNilPointer(filter, other) &&
+		utils.EqualPointers(filter.Scheme, other.Scheme) &&
+		utils.EqualPointers(filter.Hostname, other.Hostname) &&
+		filter.Path.Equal(other.Path) &&
+		utils.EqualPointers(filter.Port, other.Port) &&
+		utils.EqualPointers(filter.StatusCode, other.StatusCode))
+}
+
+func (filter *HTTPURLRewriteFilter) Equal(other *HTTPURLRewriteFilter) bool {
+	return filter == nil && other == nil || (NoNilPointer(filter, other) &&
+		utils.EqualPointers(filter.Hostname, other.Hostname) &&
+		filter.Path.Equal(other.Path))
+}
+
+func (modifier *HTTPPathModifier) Equal(other *HTTPPathModifier) bool {
+	return modifier == nil && other == nil || (NoNilPointer(modifier, other) &&
+		modifier.Type == other.Type &&
+		utils.EqualPointers(modifier.ReplaceFullPath, other.ReplaceFullPath) &&
+		utils.EqualPointers(modifier.ReplacePrefixMatch, other.ReplacePrefixMatch))
+}
+
+func (match HTTPRouteMatch) Equal(other HTTPRouteMatch, opt ...models.Options) bool {
+	return (match.Path == nil && other.Path == nil || NoNilPointer(match.Path, other.Path) && *match.Path == *other.Path) &&
+		utils.EqualPointers(match.Method, other.Method) &&
+		utils.EqualSliceComparable(match.Headers, other.Headers) &&
+		utils.EqualSliceComparable(match.QueryParams, other.QueryParams)
+}
+
+func (tls *TLSRoute) Equal(other *TLSRoute) bool {
+	return tls == nil && other == nil || (NoNilPointer(tls, other) &&
+		tls.Name == other.Name && tls.Namespace == other.Namespace &&
+		utils.EqualSliceComparable(tls.Hostnames, other.Hostnames) &&
+		BackendRefs(tls.BackendRefs).Equal(other.BackendRefs) &&
+		ParentRefs(tls.ParentRefs).Equal(other.ParentRefs))
+}
+
 type BackendRefs []BackendRef
 
 func (refs BackendRefs) Equal(other BackendRefs) bool {
@@ -293,7 +408,7 @@ func (lsr LabelSelectorRequirement) Equal(other LabelSelectorRequirement, opt ..
 }
 
 func (ns *Namespace) Equal(other *Namespace) bool {
-	return ns == nil && other == nil || (NoNilPointer(ns, other) && ns.Name == other.Name && utils.EqualMap(ns.Labels, other.Labels))
+	return ns == nil && other == nil || (NoNilPointer(ns, other) && ns.Name == other.Name && utils.EqualMap(ns.Labels, other.Labels) && utils.EqualMap(ns.Annotations, other.Annotations))
 }
 
 func (refto ReferenceGrantTo) Equal(other ReferenceGrantTo, opt ...models.Options) bool {
@@ -27,7 +27,10 @@ import (
 )
 
 const (
-	TCPProtocolType string = "TCP"
+	TCPProtocolType   string = "TCP"
+	HTTPProtocolType  string = "HTTP"
+	HTTPSProtocolType string = "HTTPS"
+	TLSProtocolType   string = "TLS"
 )
 
 type TCPResource struct {
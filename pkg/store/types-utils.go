@@ -34,3 +34,12 @@ func (tcproutes TCPRoutes) Less(i, j int) bool {
 	}
 	return tcprouteI.Namespace+tcprouteI.Name < tcprouteJ.Namespace+tcprouteJ.Name
 }
+
+func (tlsroutes TLSRoutes) Less(i, j int) bool {
+	tlsrouteI := tlsroutes[i]
+	tlsrouteJ := tlsroutes[j]
+	if !tlsrouteI.CreationTime.Equal(tlsrouteJ.CreationTime) {
+		return tlsrouteI.CreationTime.Before(tlsrouteJ.CreationTime)
+	}
+	return tlsrouteI.Namespace+tlsrouteI.Name < tlsrouteJ.Namespace+tlsrouteJ.Name
+}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/haproxytech/client-native/v6/models"
 	v3 "github.com/haproxytech/kubernetes-ingress/crs/api/ingress/v3"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // ServicePort describes port of a service
@@ -100,25 +101,31 @@ type Namespace struct {
 	Ingresses                map[string]*Ingress
 	Endpoints                map[string]map[string]*Endpoints // service -> sliceName -> Endpoints
 	Services                 map[string]*Service
+	ConfigMaps               map[string]*ConfigMap
 	HAProxyRuntime           map[string]map[string]*RuntimeBackend            // service -> portName -> Backend
 	HAProxyRuntimeStandalone map[string]map[string]map[string]*RuntimeBackend // service -> portName -> backendName -> Backend
 	CRs                      *CustomResources
 	Gateways                 map[string]*Gateway
 	TCPRoutes                map[string]*TCPRoute
+	HTTPRoutes               map[string]*HTTPRoute
+	TLSRoutes                map[string]*TLSRoute
 	ReferenceGrants          map[string]*ReferenceGrant
 	Labels                   map[string]string
+	Annotations              map[string]string
 	Name                     string
 	Status                   Status
 	Relevant                 bool
 }
 
 type CustomResources struct {
-	Global    map[string]*models.Global
-	Defaults  map[string]*models.Defaults
-	Backends  map[string]*v3.BackendSpec
-	Frontends map[string]*v3.FrontendSpec
-	TCPsPerCR map[string]*TCPs // key is the TCP CR name
-	AllTCPs   TCPResourceList
+	Global          map[string]*models.Global
+	Defaults        map[string]*models.Defaults
+	Backends        map[string]*v3.BackendSpec
+	Frontends       map[string]*v3.FrontendSpec
+	StaticResponses map[string]*v3.StaticResponseSpec
+	TrafficSplits   map[string]*v3.TrafficSplitSpec
+	TCPsPerCR       map[string]*TCPs // key is the TCP CR name
+	AllTCPs         TCPResourceList
 }
 
 type IngressClass struct {
@@ -139,6 +146,15 @@ type IngressPath struct {
 	PathTypeMatch    string
 	SvcPortInt       int64
 	IsDefaultBackend bool
+	// Resource is set instead of the service when the path backend is a custom resource
+	Resource *IngressPathResource
+}
+
+// IngressPathResource is the custom resource referenced by the backend of an ingress path
+type IngressPathResource struct {
+	APIGroup string
+	Kind     string
+	Name     string
 }
 
 // IngressRule is useful data from k8s structures about ingress rule
@@ -197,27 +213,43 @@ type IngressCore struct {
 	Name           string
 	Class          string
 	CreationTime   time.Time
+	UID            types.UID
 }
 
 type GatewayClass struct {
 	Description    *string
+	ParametersRef  *GatewayClassParametersRef
 	Name           string
 	ControllerName string
 	Status         Status
 	Generation     int64
 }
 
+type GatewayClassParametersRef struct {
+	Namespace *string
+	Group     string
+	Kind      string
+	Name      string
+}
+
 type Gateway struct {
 	Namespace        string
 	Name             string
 	GatewayClassName string
 	Status           Status
 	Listeners        []Listener
+	Addresses        []GatewayAddress
 	Generation       int64
 }
+
+type GatewayAddress struct {
+	Type  *string
+	Value string
+}
 type Listener struct {
 	Hostname      *string
 	AllowedRoutes *AllowedRoutes
+	TLS           *ListenerTLSConfig
 	Name          string
 	Protocol      string
 	GwNamespace   string
@@ -225,6 +257,18 @@ type Listener struct {
 	Port          int32
 }
 
+type ListenerTLSConfig struct {
+	Mode            string
+	CertificateRefs []SecretObjectReference
+}
+
+type SecretObjectReference struct {
+	Group     *string
+	Kind      *string
+	Namespace *string
+	Name      string
+}
+
 type AllowedRoutes struct {
 	Namespaces *RouteNamespaces
 	Kinds      []RouteGroupKind
@@ -271,6 +315,97 @@ type LabelSelectorRequirement struct {
 
 type TCPRoutes []TCPRoute
 
+type HTTPRoute struct {
+	CreationTime time.Time
+	Name         string
+	Namespace    string
+	Status       Status
+	Hostnames    []string
+	Rules        []HTTPRouteRule
+	ParentRefs   []ParentRef
+	Generation   int64
+}
+
+type HTTPRouteRule struct {
+	Matches     []HTTPRouteMatch
+	Filters     []HTTPRouteFilter
+	BackendRefs []BackendRef
+}
+
+type HTTPRouteFilter struct {
+	RequestHeaderModifier *HTTPHeaderFilter
+	RequestRedirect       *HTTPRequestRedirectFilter
+	URLRewrite            *HTTPURLRewriteFilter
+	Type                  string
+}
+
+type HTTPHeaderFilter struct {
+	Set    []HTTPHeader
+	Add    []HTTPHeader
+	Remove []string
+}
+
+type HTTPHeader struct {
+	Name  string
+	Value string
+}
+
+type HTTPRequestRedirectFilter struct {
+	Scheme     *string
+	Hostname   *string
+	Path       *HTTPPathModifier
+	Port       *int32
+	StatusCode *int
+}
+
+type HTTPURLRewriteFilter struct {
+	Hostname *string
+	Path     *HTTPPathModifier
+}
+
+type HTTPPathModifier struct {
+	ReplaceFullPath    *string
+	ReplacePrefixMatch *string
+	Type               string
+}
+
+type HTTPRouteMatch struct {
+	Path        *HTTPPathMatch
+	Method      *string
+	Headers     []HTTPHeaderMatch
+	QueryParams []HTTPQueryParamMatch
+}
+
+type HTTPPathMatch struct {
+	Type  string
+	Value string
+}
+
+type HTTPHeaderMatch struct {
+	Type  string
+	Name  string
+	Value string
+}
+
+type HTTPQueryParamMatch struct {
+	Type  string
+	Name  string
+	Value string
+}
+
+type TLSRoute struct {
+	CreationTime time.Time
+	Name         string
+	Namespace    string
+	Status       Status
+	Hostnames    []string
+	BackendRefs  []BackendRef
+	ParentRefs   []ParentRef
+	Generation   int64
+}
+
+type TLSRoutes []TLSRoute
+
 type ParentRef struct {
 	Namespace   *string
 	SectionName *string